@@ -59,12 +59,12 @@ You may specify one or more benchmarks or controls to run (separated by a space)
 		AddBoolFlag(constants.ArgHeader, true, "Include column headers for csv and table output").
 		AddBoolFlag(constants.ArgHelp, false, "Help for check", cmdconfig.FlagOptions.WithShortHand("h")).
 		AddStringFlag(constants.ArgSeparator, ",", "Separator string for csv output").
-		AddStringFlag(constants.ArgOutput, constants.OutputFormatText, "Output format: brief, csv, html, json, md, text, snapshot or none").
+		AddStringFlag(constants.ArgOutput, constants.OutputFormatText, "Output format: brief, csv, html, json, md, sarif, text, snapshot or none").
 		AddBoolFlag(constants.ArgTiming, false, "Turn on the timer which reports check time").
 		AddStringSliceFlag(constants.ArgSearchPath, nil, "Set a custom search_path for the steampipe user for a check session (comma-separated)").
 		AddStringSliceFlag(constants.ArgSearchPathPrefix, nil, "Set a prefix to the current search path for a check session (comma-separated)").
 		AddStringFlag(constants.ArgTheme, "dark", "Set the output theme for 'text' output: light, dark or plain").
-		AddStringSliceFlag(constants.ArgExport, nil, "Export output to file, supported formats: csv, html, json, md, nunit3, sps (snapshot), asff").
+		AddStringSliceFlag(constants.ArgExport, nil, "Export output to file, supported formats: csv, html, json, md, nunit3, junit, sps (snapshot), asff, sarif").
 		AddBoolFlag(constants.ArgProgress, true, "Display control execution progress").
 		AddBoolFlag(constants.ArgDryRun, false, "Show which controls will be run without running them").
 		AddStringSliceFlag(constants.ArgTag, nil, "Filter controls based on their tag values ('--tag key=value')").
@@ -74,6 +74,18 @@ You may specify one or more benchmarks or controls to run (separated by a space)
 		// where args passed to StringArrayFlag are not parsed and used raw
 		AddStringArrayFlag(constants.ArgVariable, nil, "Specify the value of a variable").
 		AddStringFlag(constants.ArgWhere, "", "SQL 'where' clause, or named query, used to filter controls (cannot be used with '--tag')").
+		AddStringFlag(constants.ArgBaseline, "", "Baseline file (or json output of a previous check run) containing known results - matching alarms and errors are reported as suppressed").
+		AddStringFlag(constants.ArgWriteBaseline, "", "Write a baseline file containing the alarm, error and suppressed results of this run").
+		AddStringFlag(constants.ArgCompareTo, "", "Compare the results with the json output of a previous check run, reporting new, resolved and unchanged alarms").
+		AddStringFlag(constants.ArgFailOn, "", "Only fail the run for alarms of controls with at least the given severity, e.g. '--fail-on severity>=high'").
+		AddIntFlag(constants.ArgMaxAlarms, 0, "The number of alarms allowed before the run fails (used with '--fail-on', or counting all alarms if not set)").
+		AddStringSliceFlag(constants.ArgStatus, nil, "Only output results with the given statuses, e.g. '--status alarm,error'").
+		AddStringArrayFlag(constants.ArgDimension, nil, "Only output results with the given dimension value ('--dimension key=value')").
+		AddStringFlag(constants.ArgGroupBy, "", "Group the results by a dimension, tag or severity instead of by benchmark: dimension:<name>, tag:<key> or severity").
+		AddBoolFlag(constants.ArgScore, false, "Compute a weighted compliance score for each benchmark, using the 'weight' of its child benchmarks and controls").
+		AddBoolFlag(constants.ArgSaveResults, false, fmt.Sprintf("Save the results of the run in the %s.%s and %s.%s tables of the local database", constants.CheckResultsSchema, constants.CheckRunTable, constants.CheckResultsSchema, constants.CheckResultTable)).
+		AddBoolFlag(constants.ArgControlCache, false, "Serve the results of unchanged controls from the control result cache, and cache the results of this run").
+		AddIntFlag(constants.ArgControlCacheTtl, constants.DefaultControlCacheTtl, "The time in seconds for which cached control results are valid").
 		AddIntFlag(constants.ArgDatabaseQueryTimeout, constants.DatabaseDefaultCheckQueryTimeout, "The query timeout").
 		AddIntFlag(constants.ArgMaxParallel, constants.DefaultMaxConnections, "The maximum number of concurrent database connections to open").
 		AddBoolFlag(constants.ArgModInstall, true, "Specify whether to install mod dependencies before running the check").
@@ -85,11 +97,12 @@ You may specify one or more benchmarks or controls to run (separated by a space)
 		AddStringFlag(constants.ArgSnapshotTitle, "", "The title to give a snapshot")
 
 	cmd.AddCommand(getListSubCmd(listSubCmdOptions{parentCmd: cmd}))
+	cmd.AddCommand(checkScheduleCmd())
 	return cmd
 }
 
-// exitCode=0 no runtime errors, no control alarms or errors
-// exitCode=1 no runtime errors, 1 or more control alarms, no control errors
+// exitCode=0 no runtime errors, no control alarms (or no alarms exceeding the failure threshold) or errors
+// exitCode=1 no runtime errors, 1 or more control alarms (exceeding the failure threshold, if set), no control errors
 // exitCode=2 no runtime errors, 1 or more control errors
 // exitCode=3+ runtime errors
 
@@ -137,8 +150,12 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 	w := initData.Workspace
 	client := initData.Client
 	totalAlarms, totalErrors := 0, 0
+	// the number of alarms counting towards the failure threshold (if set)
+	thresholdAlarms := 0
 	var durations []time.Duration
 	var exportMsg []string
+	var saveMsg []string
+	var executionTrees []*controlexecute.ExecutionTree
 
 	shouldShare := viper.GetBool(constants.ArgShare)
 	shouldUpload := viper.GetBool(constants.ArgSnapshot)
@@ -156,12 +173,22 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		// create the execution tree
 		executionTree, err := controlexecute.NewExecutionTree(ctx, w, client, targetName, initData.ControlFilterWhereClause)
 		error_helpers.FailOnError(err)
+		executionTree.Baseline = initData.Baseline
+		executionTree.PreviousRun = initData.PreviousRun
+		executionTree.FailureThreshold = initData.FailureThreshold
+		executionTree.ControlCache = initData.ControlCache
+		executionTree.ResultFilter = initData.ResultFilter
+		executionTree.GroupBy = initData.GroupBy
+		executionTree.Scoring = viper.GetBool(constants.ArgScore)
 
 		// execute controls synchronously (execute returns the number of alarms and errors)
 		stats := executionTree.Execute(ctx)
 		// append the total number of alarms and errors for multiple runs
 		totalAlarms += stats.Alarm
 		totalErrors += stats.Error
+		if executionTree.ThresholdResult != nil {
+			thresholdAlarms += executionTree.ThresholdResult.Alarms
+		}
 		err = displayControlResults(ctx, executionTree, initData.OutputFormatter)
 		error_helpers.FailOnError(err)
 
@@ -173,6 +200,13 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		exportMsg, err = initData.ExportManager.DoExport(ctx, exportName, executionTree, exportArgs)
 		error_helpers.FailOnError(err)
 
+		// if the save-results arg is set, store the results in the check results tables (a dry run has no results)
+		if viper.GetBool(constants.ArgSaveResults) && !viper.GetBool(constants.ArgDryRun) {
+			runId, err := executionTree.SaveResults(ctx, targetName, "")
+			error_helpers.FailOnError(err)
+			saveMsg = append(saveMsg, fmt.Sprintf("Results of '%s' saved with run id %s", targetName, runId))
+		}
+
 		// if the share args are set, create a snapshot and share it
 		if generateSnapshot {
 			err = controldisplay.PublishSnapshot(ctx, executionTree, shouldShare)
@@ -183,6 +217,15 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		}
 
 		durations = append(durations, executionTree.EndTime.Sub(executionTree.StartTime))
+		executionTrees = append(executionTrees, executionTree)
+	}
+
+	if initData.Baseline != nil {
+		showExpiredSuppressions(initData.Baseline)
+	}
+	if baselinePath := viper.GetString(constants.ArgWriteBaseline); baselinePath != "" {
+		err := writeBaseline(baselinePath, executionTrees, initData.Baseline)
+		error_helpers.FailOnErrorWithMessage(err, "failed to write baseline")
 	}
 
 	if shouldPrintTiming() {
@@ -196,10 +239,45 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		fmt.Printf("\n")
 	}
 
+	// print the run ids of the saved results if progress=true
+	if len(saveMsg) > 0 && viper.GetBool(constants.ArgProgress) {
+		fmt.Printf("\n")
+		fmt.Println(strings.Join(saveMsg, "\n"))
+		fmt.Printf("\n")
+	}
+
+	// if there is a failure threshold, alarms only fail the run if the threshold is exceeded
+	if initData.FailureThreshold != nil && !initData.FailureThreshold.IsExceeded(thresholdAlarms) {
+		totalAlarms = 0
+	}
+
 	// set the defined exit code after successful execution
 	exitCode = getExitCode(totalAlarms, totalErrors)
 }
 
+// writeBaseline writes a baseline file containing the alarm, error and suppressed results of the execution trees,
+// retaining the expiry of any suppressions in the current baseline
+func writeBaseline(path string, executionTrees []*controlexecute.ExecutionTree, currentBaseline *controlexecute.Baseline) error {
+	baseline := controlexecute.NewBaseline()
+	for _, executionTree := range executionTrees {
+		baseline.AddExecutionTree(executionTree, currentBaseline)
+	}
+	return baseline.Save(path)
+}
+
+// showExpiredSuppressions warns of any baseline suppressions which were not applied as they have expired
+func showExpiredSuppressions(baseline *controlexecute.Baseline) {
+	expired := baseline.ExpiredSuppressions()
+	if len(expired) == 0 {
+		return
+	}
+	warning := fmt.Sprintf("%d expired baseline %s not applied:", len(expired), utils.Pluralize("suppression", len(expired)))
+	for _, s := range expired {
+		warning += fmt.Sprintf("\n  %s - expired %s", s, s.Expires)
+	}
+	error_helpers.ShowWarning(warning)
+}
+
 // get the exit code for successful check run
 func getExitCode(alarms int, errors int) int {
 	// 1 or more control errors, return exitCode=2
@@ -246,6 +324,37 @@ func validateCheckArgs(ctx context.Context, cmd *cobra.Command, args []string) b
 		return false
 	}
 
+	// validate the failure threshold
+	if _, err := controlexecute.NewFailureThreshold(viper.GetString(constants.ArgFailOn), viper.GetInt(constants.ArgMaxAlarms)); err != nil {
+		error_helpers.ShowError(ctx, err)
+		return false
+	}
+
+	// validate the result filter
+	if _, err := controlexecute.NewResultFilter(viper.GetStringSlice(constants.ArgStatus), viper.GetStringSlice(constants.ArgDimension)); err != nil {
+		error_helpers.ShowError(ctx, err)
+		return false
+	}
+
+	// validate the result grouping
+	if groupBy := viper.GetString(constants.ArgGroupBy); groupBy != "" {
+		if _, err := controlexecute.NewGroupBy(groupBy); err != nil {
+			error_helpers.ShowError(ctx, err)
+			return false
+		}
+	}
+
+	// results can only be saved in the local database, where the check results tables are created
+	if viper.GetBool(constants.ArgSaveResults) && viper.GetString(constants.ArgWorkspaceDatabase) != constants.DefaultWorkspaceDatabase {
+		error_helpers.ShowError(ctx, fmt.Errorf("'--%s' is only supported when using the local database", constants.ArgSaveResults))
+		return false
+	}
+
+	if viper.GetInt(constants.ArgControlCacheTtl) <= 0 {
+		error_helpers.ShowError(ctx, fmt.Errorf("'--%s' must be greater than 0", constants.ArgControlCacheTtl))
+		return false
+	}
+
 	// if both '--where' and '--tag' have been used, then it's an error
 	if viper.IsSet(constants.ArgWhere) && viper.IsSet(constants.ArgTag) {
 		error_helpers.ShowError(ctx, fmt.Errorf("only 1 of '--%s' and '--%s' may be set", constants.ArgWhere, constants.ArgTag))
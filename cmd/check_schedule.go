@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/pkg/cmdconfig"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/contexthelpers"
+	"github.com/turbot/steampipe/pkg/control/controlschedule"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/initialisation"
+	"github.com/turbot/steampipe/pkg/statushooks"
+	"github.com/turbot/steampipe/pkg/steampipeconfig"
+	"github.com/turbot/steampipe/pkg/utils"
+	"github.com/turbot/steampipe/pkg/workspace"
+)
+
+func checkScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "schedule",
+		TraverseChildren: true,
+		Args:             cobra.NoArgs,
+		Run:              runCheckScheduleCmd,
+		Short:            "Execute the check runs scheduled in the workspace profile",
+		Long: `Execute the check runs scheduled in the workspace profile.
+
+Each schedule runs a benchmark or control on a cron schedule, saving the results in the
+steampipe_check schema of the database. The command runs until it is interrupted - to run
+the schedules with the Steampipe service, use 'steampipe service start --check-schedule'.`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, false, "Help for check schedule", cmdconfig.FlagOptions.WithShortHand("h")).
+		AddStringSliceFlag(constants.ArgVarFile, nil, "Specify an .spvar file containing variable values").
+		// NOTE: use StringArrayFlag for ArgVariable, not StringSliceFlag
+		// Cobra will interpret values passed to a StringSliceFlag as CSV,
+		// where args passed to StringArrayFlag are not parsed and used raw
+		AddStringArrayFlag(constants.ArgVariable, nil, "Specify the value of a variable").
+		AddIntFlag(constants.ArgDatabaseQueryTimeout, constants.DatabaseDefaultCheckQueryTimeout, "The query timeout").
+		AddIntFlag(constants.ArgMaxParallel, constants.DefaultMaxConnections, "The maximum number of concurrent database connections to open").
+		AddBoolFlag(constants.ArgModInstall, true, "Specify whether to install mod dependencies before running the schedules").
+		AddBoolFlag(constants.ArgInput, true, "Enable interactive prompts").
+		AddBoolFlag(constants.ArgServiceMode, false, "Hidden flag to specify whether this is starting as a service", cmdconfig.FlagOptions.Hidden())
+
+	return cmd
+}
+
+func runCheckScheduleCmd(cmd *cobra.Command, _ []string) {
+	utils.LogTime("runCheckScheduleCmd start")
+
+	// setup a cancel context and start cancel handler
+	ctx, cancel := context.WithCancel(cmd.Context())
+	contexthelpers.StartCancelHandler(cancel)
+
+	defer func() {
+		utils.LogTime("runCheckScheduleCmd end")
+		if r := recover(); r != nil {
+			error_helpers.ShowError(ctx, helpers.ToError(r))
+			exitCode = constants.ExitCodeUnknownErrorPanic
+		}
+	}()
+
+	schedules := steampipeconfig.GlobalWorkspaceProfile.Schedules
+	if len(schedules) == 0 {
+		exitCode = constants.ExitCodeInsufficientOrWrongInputs
+		error_helpers.FailOnError(fmt.Errorf("no check schedules are defined in workspace profile '%s'", steampipeconfig.GlobalWorkspaceProfile.Name()))
+	}
+
+	// if diagnostic mode is set, print out config and return
+	if _, ok := os.LookupEnv(constants.EnvDiagnostics); ok {
+		cmdconfig.DisplayConfig()
+		return
+	}
+
+	// runs are executed unattended - disable progress display and status messages
+	viper.Set(constants.ArgProgress, false)
+	ctx = statushooks.DisableStatusHooks(ctx)
+
+	initData := getCheckScheduleInitData(ctx)
+	defer initData.Cleanup(ctx)
+	if initData.Result.Error != nil {
+		exitCode = constants.ExitCodeInitializationFailed
+		error_helpers.FailOnError(initData.Result.Error)
+	}
+	// if there is a usage warning we display it
+	initData.Result.DisplayMessages()
+
+	scheduler, err := controlschedule.NewScheduler(ctx, schedules, initData.Workspace, initData.Client)
+	if err != nil {
+		exitCode = constants.ExitCodeInsufficientOrWrongInputs
+		error_helpers.FailOnError(err)
+	}
+	if !viper.GetBool(constants.ArgServiceMode) {
+		fmt.Println("Hit Ctrl+C to stop the check schedules")
+	}
+
+	// run until cancelled
+	scheduler.Run(ctx)
+
+	log.Println("[TRACE] runCheckScheduleCmd exiting")
+}
+
+func getCheckScheduleInitData(ctx context.Context) *initialisation.InitData {
+	w, errAndWarnings := workspace.LoadWorkspacePromptingForVariables(ctx)
+	if errAndWarnings.GetError() != nil {
+		return initialisation.NewErrorInitData(fmt.Errorf("failed to load workspace: %s", errAndWarnings.GetError().Error()))
+	}
+
+	i := initialisation.NewInitData()
+	i.Workspace = w
+	i.Init(ctx, constants.InvokerCheck)
+	return i
+}
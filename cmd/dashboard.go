@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -294,6 +295,10 @@ func runSingleDashboard(ctx context.Context, targetName string, inputs map[strin
 	snap, err := dashboardexecute.GenerateSnapshot(ctx, targetName, initData, inputs)
 	if err != nil {
 		exitCode = constants.ExitCodeSnapshotCreationFailed
+		var missingInputsErr dashboardexecute.MissingInputsError
+		if errors.As(err, &missingInputsErr) {
+			return fmt.Errorf("%s - inputs must be provided using '--dashboard-input name=value'", err.Error())
+		}
 		return err
 	}
 	// display the snapshot result (if needed)
@@ -23,6 +23,7 @@ import (
 	"github.com/turbot/steampipe/pkg/query"
 	"github.com/turbot/steampipe/pkg/query/queryexecute"
 	"github.com/turbot/steampipe/pkg/query/queryresult"
+	"github.com/turbot/steampipe/pkg/query/querysnippets"
 	"github.com/turbot/steampipe/pkg/statushooks"
 	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/pkg/utils"
@@ -48,7 +49,20 @@ Examples:
   steampipe query
 
   # Run a specific query directly
-  steampipe query "select * from cloud"`,
+  steampipe query "select * from cloud"
+
+  # Run a query, binding values to its parameters
+  steampipe query "select * from aws_s3_bucket where region = $1" --arg us-east-1
+
+  # Export the results of several queries to a sqlite database, with a table per query
+  # (if the file already exists, the results are appended to the existing tables)
+  steampipe query query.s3_buckets query.ec2_instances --export inventory.sqlite
+
+  # Run a snippet saved in the interactive console using '.save my_snippet'
+  steampipe query my_snippet
+
+  # List the query history (to run a named query called 'history', use 'steampipe query query.history')
+  steampipe query history`,
 
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			ctx := cmd.Context()
@@ -62,6 +76,14 @@ Examples:
 					namedQueries = append(namedQueries, name)
 				}
 			}
+			// also complete saved snippets
+			if snippets, err := querysnippets.Load(); err == nil {
+				for _, snippet := range snippets.List() {
+					if strings.HasPrefix(snippet.Name, toComplete) {
+						namedQueries = append(namedQueries, snippet.Name)
+					}
+				}
+			}
 			return namedQueries, cobra.ShellCompDirectiveNoFileComp
 		},
 	}
@@ -73,7 +95,7 @@ Examples:
 		AddBoolFlag(constants.ArgHelp, false, "Help for query", cmdconfig.FlagOptions.WithShortHand("h")).
 		AddBoolFlag(constants.ArgHeader, true, "Include column headers csv and table output").
 		AddStringFlag(constants.ArgSeparator, ",", "Separator string for csv output").
-		AddStringFlag(constants.ArgOutput, "table", "Output format: line, csv, json, table or snapshot").
+		AddStringFlag(constants.ArgOutput, "table", "Output format: line, csv, json, jsonl, table, parquet, arrow or snapshot").
 		AddBoolFlag(constants.ArgTiming, false, "Turn on the timer which reports query time").
 		AddBoolFlag(constants.ArgWatch, true, "Watch SQL files in the current workspace (works only in interactive mode)").
 		AddStringSliceFlag(constants.ArgSearchPath, nil, "Set a custom search_path for the steampipe user for a query session (comma-separated)").
@@ -83,17 +105,23 @@ Examples:
 		// Cobra will interpret values passed to a StringSliceFlag as CSV,
 		// where args passed to StringArrayFlag are not parsed and used raw
 		AddStringArrayFlag(constants.ArgVariable, nil, "Specify the value of a variable").
+		// NOTE: use StringArrayFlag for ArgArg, for the same reason as ArgVariable
+		AddStringArrayFlag(constants.ArgArg, nil, "Specify the value of a query parameter, either positional (value) or named (name=value)").
 		AddBoolFlag(constants.ArgInput, true, "Enable interactive prompts").
 		AddBoolFlag(constants.ArgSnapshot, false, "Create snapshot in Steampipe Cloud with the default (workspace) visibility").
 		AddBoolFlag(constants.ArgShare, false, "Create snapshot in Steampipe Cloud with 'anyone_with_link' visibility").
 		AddStringArrayFlag(constants.ArgSnapshotTag, nil, "Specify tags to set on the snapshot").
 		AddStringFlag(constants.ArgSnapshotTitle, "", "The title to give a snapshot").
 		AddIntFlag(constants.ArgDatabaseQueryTimeout, 0, "The query timeout").
-		AddStringSliceFlag(constants.ArgExport, nil, "Export output to file, supported format: sps (snapshot)").
+		AddIntFlag(constants.ArgParallel, 1, "The number of queries to execute in parallel (batch mode only)").
+		AddStringFlag(constants.ArgDiffAgainst, "", "Compare the result to the json output of a previous run, showing added, removed and changed rows").
+		AddStringSliceFlag(constants.ArgDiffKey, nil, "The columns used to match rows when comparing results with --diff-against (comma-separated)").
+		AddStringSliceFlag(constants.ArgExport, nil, "Export output to file, supported formats: sps (snapshot), parquet, arrow, sqlite").
 		AddStringFlag(constants.ArgSnapshotLocation, "", "The location to write snapshots - either a local file path or a Steampipe Cloud workspace").
 		AddBoolFlag(constants.ArgProgress, true, "Display snapshot upload status")
 
 	cmd.AddCommand(getListSubCmd(listSubCmdOptions{parentCmd: cmd}))
+	cmd.AddCommand(queryHistoryCmd())
 
 	return cmd
 }
@@ -161,6 +189,27 @@ func runQueryCmd(cmd *cobra.Command, args []string) {
 	}
 }
 
+// validateDiffArgs validates the --diff-against and --diff-key args
+func validateDiffArgs(args []string) error {
+	if !viper.IsSet(constants.ArgDiffAgainst) {
+		if viper.IsSet(constants.ArgDiffKey) {
+			return fmt.Errorf("--%s can only be used with --%s", constants.ArgDiffKey, constants.ArgDiffAgainst)
+		}
+		return nil
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("--%s requires a single query", constants.ArgDiffAgainst)
+	}
+	if snapshotRequired() {
+		return fmt.Errorf("--%s cannot be used when creating a snapshot", constants.ArgDiffAgainst)
+	}
+	validOutputFormats := []string{constants.OutputFormatLine, constants.OutputFormatCSV, constants.OutputFormatTable, constants.OutputFormatJSON, constants.OutputFormatJSONL, constants.OutputFormatNone}
+	if output := viper.GetString(constants.ArgOutput); !helpers.StringSliceContains(validOutputFormats, output) {
+		return fmt.Errorf("--%s does not support output format '%s', must be one of [%s]", constants.ArgDiffAgainst, output, strings.Join(validOutputFormats, ", "))
+	}
+	return nil
+}
+
 func validateQueryArgs(ctx context.Context, args []string) error {
 	interactiveMode := len(args) == 0
 	if interactiveMode && (viper.IsSet(constants.ArgSnapshot) || viper.IsSet(constants.ArgShare)) {
@@ -171,6 +220,14 @@ func validateQueryArgs(ctx context.Context, args []string) error {
 		exitCode = constants.ExitCodeInsufficientOrWrongInputs
 		return fmt.Errorf("cannot export query results in interactive mode")
 	}
+	if interactiveMode && len(viper.GetStringSlice(constants.ArgArg)) > 0 {
+		exitCode = constants.ExitCodeInsufficientOrWrongInputs
+		return fmt.Errorf("cannot pass query args in interactive mode")
+	}
+	if err := validateDiffArgs(args); err != nil {
+		exitCode = constants.ExitCodeInsufficientOrWrongInputs
+		return err
+	}
 	// if share or snapshot args are set, there must be a query specified
 	err := cmdconfig.ValidateSnapshotArgs(ctx)
 	if err != nil {
@@ -178,12 +235,27 @@ func validateQueryArgs(ctx context.Context, args []string) error {
 		return err
 	}
 
-	validOutputFormats := []string{constants.OutputFormatLine, constants.OutputFormatCSV, constants.OutputFormatTable, constants.OutputFormatJSON, constants.OutputFormatSnapshot, constants.OutputFormatSnapshotShort, constants.OutputFormatNone}
+	validOutputFormats := []string{constants.OutputFormatLine, constants.OutputFormatCSV, constants.OutputFormatTable, constants.OutputFormatJSON, constants.OutputFormatJSONL, constants.OutputFormatParquet, constants.OutputFormatArrow, constants.OutputFormatSnapshot, constants.OutputFormatSnapshotShort, constants.OutputFormatNone}
 	output := viper.GetString(constants.ArgOutput)
 	if !helpers.StringSliceContains(validOutputFormats, output) {
 		exitCode = constants.ExitCodeInsufficientOrWrongInputs
 		return fmt.Errorf("invalid output format: '%s', must be one of [%s]", output, strings.Join(validOutputFormats, ", "))
 	}
+	if viper.GetInt(constants.ArgParallel) < 1 {
+		exitCode = constants.ExitCodeInsufficientOrWrongInputs
+		return fmt.Errorf("invalid value for --%s: must be at least 1", constants.ArgParallel)
+	}
+	// the timing information is read from the shared client state, which cannot be used by concurrent queries
+	if viper.GetInt(constants.ArgParallel) > 1 && viper.GetBool(constants.ArgTiming) {
+		exitCode = constants.ExitCodeInsufficientOrWrongInputs
+		return fmt.Errorf("'--%s' cannot be used when '--%s' is greater than 1", constants.ArgTiming, constants.ArgParallel)
+	}
+
+	// binary output formats cannot be displayed in the interactive console
+	if interactiveMode && (output == constants.OutputFormatParquet || output == constants.OutputFormatArrow) {
+		exitCode = constants.ExitCodeInsufficientOrWrongInputs
+		return fmt.Errorf("cannot use '%s' output in interactive mode", output)
+	}
 
 	return nil
 }
@@ -252,8 +324,7 @@ func executeSnapshotQuery(initData *query.InitData, ctx context.Context) int {
 			}
 
 			// export the result if necessary
-			exportArgs := viper.GetStringSlice(constants.ArgExport)
-			exportMsg, err := initData.ExportManager.DoExport(ctx, snap.FileNameRoot, snap, exportArgs)
+			exportMsg, err := exportSnapshotQuery(ctx, initData, name, snap)
 			error_helpers.FailOnErrorWithMessage(err, "failed to export snapshot")
 			// print the location where the file is exported
 			if len(exportMsg) > 0 && viper.GetBool(constants.ArgProgress) {
@@ -266,6 +337,35 @@ func executeSnapshotQuery(initData *query.InitData, ctx context.Context) int {
 	return 0
 }
 
+// export the snapshot to all snapshot export targets, and the query result it contains to all other export targets
+func exportSnapshotQuery(ctx context.Context, initData *query.InitData, queryName string, snap *dashboardtypes.SteampipeSnapshot) ([]string, error) {
+	var snapshotExportArgs []string
+	var exportMsgs []string
+	for _, e := range viper.GetStringSlice(constants.ArgExport) {
+		if isSnapshotExport(e) {
+			snapshotExportArgs = append(snapshotExportArgs, e)
+			continue
+		}
+		// the query result can only be read once, so convert the snapshot for each target
+		result, err := snapshotToQueryResult(snap)
+		if err != nil {
+			return nil, err
+		}
+		result.QueryName = queryName
+		msgs, err := initData.ExportManager.DoExport(ctx, snap.FileNameRoot, result, []string{e})
+		if err != nil {
+			return nil, err
+		}
+		exportMsgs = append(exportMsgs, msgs...)
+	}
+
+	msgs, err := initData.ExportManager.DoExport(ctx, snap.FileNameRoot, snap, snapshotExportArgs)
+	if err != nil {
+		return nil, err
+	}
+	return append(exportMsgs, msgs...), nil
+}
+
 func snapshotToQueryResult(snap *dashboardtypes.SteampipeSnapshot) (*queryresult.Result, error) {
 	// the table of a snapshot query has a fixed name
 	tablePanel, ok := snap.Panels[modconfig.SnapshotQueryTableName]
@@ -332,7 +432,7 @@ func snapshotRequired() bool {
 	SnapshotFormatNames := []string{constants.OutputFormatSnapshot, constants.OutputFormatSnapshotShort}
 	// if a snapshot exporter is specified return true
 	for _, e := range viper.GetStringSlice(constants.ArgExport) {
-		if helpers.StringSliceContains(SnapshotFormatNames, e) || path.Ext(e) == constants.SnapshotExtension {
+		if isSnapshotExport(e) {
 			return true
 		}
 	}
@@ -343,6 +443,11 @@ func snapshotRequired() bool {
 
 }
 
+// isSnapshotExport returns whether the given export arg specifies a snapshot export
+func isSnapshotExport(e string) bool {
+	return helpers.StringSliceContains([]string{constants.OutputFormatSnapshot, constants.OutputFormatSnapshotShort}, e) || path.Ext(e) == constants.SnapshotExtension
+}
+
 // getPipedStdinData reads the Standard Input and returns the available data as a string
 // if and only if the data was piped to the process
 func getPipedStdinData() string {
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/pkg/cmdconfig"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/query/queryhistory"
+)
+
+func queryHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "history [search term]",
+		TraverseChildren: true,
+		Args:             cobra.ArbitraryArgs,
+		Run:              runQueryHistoryCmd,
+		Short:            "List or export the query history",
+		Long: `List or export the history of queries executed in the interactive query console.
+
+If a search term is passed, only entries whose query, workspace or error contain
+the term are listed.
+
+NOTE: 'steampipe query history' always runs this command, so a named query called
+'history' must be run using its qualified name, e.g. 'steampipe query query.history',
+and a snippet called 'history' can only be run from the interactive console.
+
+Examples:
+
+  # List the query history
+  steampipe query history
+
+  # List history entries containing 'aws_s3_bucket'
+  steampipe query history aws_s3_bucket
+
+  # Export the query history as csv
+  steampipe query history --output csv > history.csv`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, false, "Help for query history", cmdconfig.FlagOptions.WithShortHand("h")).
+		AddStringFlag(constants.ArgOutput, constants.OutputFormatTable, "Output format: table, json or csv")
+
+	return cmd
+}
+
+func runQueryHistoryCmd(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	defer func() {
+		if r := recover(); r != nil {
+			error_helpers.ShowError(ctx, helpers.ToError(r))
+			exitCode = constants.ExitCodeUnknownErrorPanic
+		}
+	}()
+
+	output := viper.GetString(constants.ArgOutput)
+	validOutputFormats := []string{constants.OutputFormatTable, constants.OutputFormatJSON, constants.OutputFormatCSV}
+	if !helpers.StringSliceContains(validOutputFormats, output) {
+		exitCode = constants.ExitCodeInsufficientOrWrongInputs
+		error_helpers.ShowError(ctx, fmt.Errorf("invalid output format: '%s', must be one of [%s]", output, strings.Join(validOutputFormats, ", ")))
+		return
+	}
+
+	history, err := queryhistory.New()
+	error_helpers.FailOnErrorWithMessage(err, "failed to load query history")
+
+	// build the list of matching entries, along with their position in the history
+	term := strings.Join(args, " ")
+	var entries []*queryhistory.HistoryEntry
+	var rows [][]string
+	for i, entry := range history.Entries() {
+		if term != "" && !entry.Matches(term) {
+			continue
+		}
+		entries = append(entries, entry)
+		rows = append(rows, entry.DisplayRow(i+1))
+	}
+
+	switch output {
+	case constants.OutputFormatJSON:
+		if entries == nil {
+			entries = []*queryhistory.HistoryEntry{}
+		}
+		jsonOutput, err := json.MarshalIndent(entries, "", "  ")
+		error_helpers.FailOnError(err)
+		fmt.Println(string(jsonOutput))
+	case constants.OutputFormatCSV:
+		writer := csv.NewWriter(os.Stdout)
+		error_helpers.FailOnError(writer.Write(queryhistory.DisplayHeaders))
+		error_helpers.FailOnError(writer.WriteAll(rows))
+	default:
+		if len(rows) == 0 {
+			fmt.Println("No matching history entries.")
+			return
+		}
+		display.ShowWrappedTable(queryhistory.DisplayHeaders, rows, &display.ShowWrappedTableOptions{
+			AutoMerge:        false,
+			HideEmptyColumns: true,
+			Truncate:         true,
+		})
+	}
+}
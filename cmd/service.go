@@ -15,11 +15,13 @@ import (
 	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe/pkg/cmdconfig"
 	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controlschedule"
 	"github.com/turbot/steampipe/pkg/dashboard/dashboardserver"
 	"github.com/turbot/steampipe/pkg/db/db_local"
 	"github.com/turbot/steampipe/pkg/display"
 	"github.com/turbot/steampipe/pkg/error_helpers"
 	"github.com/turbot/steampipe/pkg/statushooks"
+	"github.com/turbot/steampipe/pkg/steampipeconfig"
 	"github.com/turbot/steampipe/pkg/utils"
 	"github.com/turbot/steampipe/pluginmanager"
 )
@@ -70,15 +72,17 @@ connection from any Postgres compatible database client.`,
 		AddBoolFlag(constants.ArgDashboard, false, "Run the dashboard webserver with the service").
 		AddStringFlag(constants.ArgDashboardListen, string(dashboardserver.ListenTypeNetwork), "Accept connections from: local (localhost only) or network (open) (dashboard)").
 		AddIntFlag(constants.ArgDashboardPort, constants.DashboardServerDefaultPort, "Report server port").
+		// check scheduler
+		AddBoolFlag(constants.ArgCheckSchedule, false, "Run the check schedules defined in the workspace profile with the service").
 		// foreground enables the service to run in the foreground - till exit
 		AddBoolFlag(constants.ArgForeground, false, "Run the service in the foreground").
 
-		// flags relevant only if the --dashboard arg is used:
-		AddStringSliceFlag(constants.ArgVarFile, nil, "Specify an .spvar file containing variable values (only applies if '--dashboard' flag is also set)").
+		// flags relevant only if the --dashboard or --check-schedule arg is used:
+		AddStringSliceFlag(constants.ArgVarFile, nil, "Specify an .spvar file containing variable values (only applies if '--dashboard' or '--check-schedule' flag is also set)").
 		// NOTE: use StringArrayFlag for ArgVariable, not StringSliceFlag
 		// Cobra will interpret values passed to a StringSliceFlag as CSV,
 		// where args passed to StringArrayFlag are not parsed and used raw
-		AddStringArrayFlag(constants.ArgVariable, nil, "Specify the value of a variable (only applies if '--dashboard' flag is also set)").
+		AddStringArrayFlag(constants.ArgVariable, nil, "Specify the value of a variable (only applies if '--dashboard' or '--check-schedule' flag is also set)").
 
 		// hidden flags for internal use
 		AddStringFlag(constants.ArgInvoker, string(constants.InvokerService), "Invoked by \"service\" or \"query\"", cmdconfig.FlagOptions.Hidden())
@@ -184,6 +188,7 @@ func runServiceStartCmd(cmd *cobra.Command, _ []string) {
 	alreadyRunning := !dbServiceStarted
 
 	printStatus(ctx, startResult.DbState, startResult.PluginManagerState, dashboardState, alreadyRunning)
+	printCheckSchedulerStatus()
 
 	if viper.GetBool(constants.ArgForeground) {
 		runServiceInForeground(ctx)
@@ -255,6 +260,19 @@ func startService(ctx context.Context, port int, serviceListen db_local.StartLis
 			dbServiceStarted = true
 		}
 	}
+
+	if viper.GetBool(constants.ArgCheckSchedule) {
+		schedulerState, err := controlschedule.GetSchedulerServiceState()
+		if err == nil && schedulerState == nil {
+			_, err = controlschedule.RunForService(ctx, steampipeconfig.GlobalWorkspaceProfile.Schedules)
+			dbServiceStarted = true
+		}
+		if err != nil {
+			tryToStopServices(ctx)
+			exitCode = constants.ExitCodeServiceStartupFailure
+			error_helpers.FailOnError(err)
+		}
+	}
 	return startResult, dashboardState, dbServiceStarted
 }
 
@@ -267,6 +285,10 @@ func tryToStopServices(ctx context.Context) {
 	if err := dashboardserver.StopDashboardService(ctx); err != nil {
 		error_helpers.ShowError(ctx, err)
 	}
+	// stop the check scheduler
+	if err := controlschedule.StopSchedulerService(ctx); err != nil {
+		error_helpers.ShowError(ctx, err)
+	}
 }
 
 func startDashboardServer(ctx context.Context) (*dashboardserver.DashboardServiceState, error) {
@@ -329,6 +351,7 @@ func runServiceInForeground(ctx context.Context) {
 		case <-sigIntChannel:
 			fmt.Print("\r")
 			dashboardserver.StopDashboardService(ctx)
+			controlschedule.StopSchedulerService(ctx)
 			// if we have received this signal, then the user probably wants to shut down
 			// everything. Shutdowns MUST NOT happen in cancellable contexts
 			connectedClients, err := db_local.GetClientCount(context.Background())
@@ -377,6 +400,7 @@ func runServiceRestartCmd(cmd *cobra.Command, _ []string) {
 
 	if dbStartResult != nil {
 		printStatus(ctx, dbStartResult.DbState, dbStartResult.PluginManagerState, currentDashboardState, false)
+		printCheckSchedulerStatus()
 	}
 }
 
@@ -396,6 +420,17 @@ func restartService(ctx context.Context) (_ *db_local.StartResult, _ *dashboards
 	currentDashboardState, err := dashboardserver.GetDashboardServiceState()
 	error_helpers.FailOnError(err)
 
+	// and the current check scheduler state - maybe nil
+	currentSchedulerState, err := controlschedule.GetSchedulerServiceState()
+	error_helpers.FailOnError(err)
+
+	// stop the check scheduler first - it is a client of the db service
+	err = controlschedule.StopSchedulerService(ctx)
+	if err != nil {
+		exitCode = constants.ExitCodeServiceStopFailure
+		error_helpers.FailOnErrorWithMessage(err, "could not stop check scheduler")
+	}
+
 	// stop db
 	stopStatus, err := db_local.StopServices(ctx, viper.GetBool(constants.ArgForce), constants.InvokerService)
 	if err != nil {
@@ -452,6 +487,13 @@ to force a restart.
 		error_helpers.FailOnError(err)
 	}
 
+	// if the check scheduler was running, start it
+	if currentSchedulerState != nil {
+		if _, err = controlschedule.RunForService(ctx, steampipeconfig.GlobalWorkspaceProfile.Schedules); err != nil {
+			error_helpers.ShowWarning(fmt.Sprintf("could not restart the check scheduler: %s", err.Error()))
+		}
+	}
+
 	return dbStartResult, currentDashboardState
 }
 
@@ -481,6 +523,7 @@ func runServiceStatusCmd(cmd *cobra.Command, _ []string) {
 			return
 		}
 		printStatus(ctx, dbState, pmState, dashboardState, false)
+		printCheckSchedulerStatus()
 	}
 }
 
@@ -527,8 +570,9 @@ func runServiceStopCmd(cmd *cobra.Command, _ []string) {
 	force := cmdconfig.Viper().GetBool(constants.ArgForce)
 	if force {
 		dashboardStopError := dashboardserver.StopDashboardService(ctx)
+		schedulerStopError := controlschedule.StopSchedulerService(ctx)
 		status, dbStopError = db_local.StopServices(ctx, force, constants.InvokerService)
-		dbStopError = error_helpers.CombineErrors(dbStopError, dashboardStopError)
+		dbStopError = error_helpers.CombineErrors(dbStopError, dashboardStopError, schedulerStopError)
 		if dbStopError != nil {
 			exitCode = constants.ExitCodeServiceStopFailure
 			error_helpers.FailOnError(dbStopError)
@@ -563,6 +607,13 @@ func runServiceStopCmd(cmd *cobra.Command, _ []string) {
 			}
 		}
 
+		// the check scheduler is a client of the db service, so must be stopped before counting clients
+		err = controlschedule.StopSchedulerService(ctx)
+		if err != nil {
+			exitCode = constants.ExitCodeServiceStopFailure
+			error_helpers.FailOnErrorWithMessage(err, "could not stop check scheduler")
+		}
+
 		// check if there are any connected clients to the service
 		connectedClients, err := db_local.GetClientCount(cmd.Context())
 		if err != nil {
@@ -776,6 +827,22 @@ Please use %s to recover the service
 	}
 }
 
+// printCheckSchedulerStatus prints the schedules being run by the check scheduler (if it is running)
+func printCheckSchedulerStatus() {
+	schedulerState, err := controlschedule.GetSchedulerServiceState()
+	if err != nil || schedulerState == nil {
+		return
+	}
+	fmt.Printf(`Check scheduler:
+
+  Schedules:  %s
+  Results:    %s.%s, %s.%s
+
+`, strings.Join(schedulerState.Schedules, ", "),
+		constants.CheckResultsSchema, constants.CheckRunTable,
+		constants.CheckResultsSchema, constants.CheckResultTable)
+}
+
 func printRunningImplicit(invoker constants.Invoker) {
 	fmt.Printf(`
 Steampipe service is running exclusively for an active %s session.
@@ -92,8 +92,11 @@ func setBaseDefaults() {
 		constants.ArgUpdateCheck: true,
 
 		// workspace profile
-		constants.ArgAutoComplete:  true,
-		constants.ArgIntrospection: constants.IntrospectionNone,
+		constants.ArgAutoComplete:   true,
+		constants.ArgIntrospection:  constants.IntrospectionNone,
+		constants.ArgHistorySize:    constants.HistorySize,
+		constants.ArgMaxColumnWidth: constants.MaxColumnWidth,
+		constants.ArgJsonFormat:     constants.JsonFormatCompact,
 
 		// from global database options
 		constants.ArgDatabasePort:         constants.DatabaseDefaultPort,
@@ -35,11 +35,28 @@ const (
 	ArgProgress              = "progress"
 	ArgExport                = "export"
 	ArgMaxParallel           = "max-parallel"
+	ArgParallel              = "parallel"
 	ArgLogLevel              = "log-level"
 	ArgDryRun                = "dry-run"
 	ArgWhere                 = "where"
 	ArgTag                   = "tag"
+	ArgBaseline              = "baseline"
+	ArgWriteBaseline         = "write-baseline"
+	ArgCompareTo             = "compare-to"
+	ArgFailOn                = "fail-on"
+	ArgMaxAlarms             = "max-alarms"
+	ArgControlCache          = "control-cache"
+	ArgControlCacheTtl       = "control-cache-ttl"
+	ArgStatus                = "status"
+	ArgDimension             = "dimension"
+	ArgGroupBy               = "group-by"
+	ArgScore                 = "score"
+	ArgCheckSchedule         = "check-schedule"
+	ArgSaveResults           = "save-results"
 	ArgVariable              = "var"
+	ArgArg                   = "arg"
+	ArgDiffAgainst           = "diff-against"
+	ArgDiffKey               = "diff-key"
 	ArgVarFile               = "var-file"
 	ArgConnectionString      = "connection-string"
 	ArgCheckDisplayWidth     = "check-display-width"
@@ -64,6 +81,14 @@ const (
 	ArgSnapshotTitle         = "snapshot-title"
 	ArgDatabaseStartTimeout  = "database-start-timeout"
 	ArgDashboardStartTimeout = "dashboard-start-timeout"
+	ArgHistorySize           = "history-size"
+	ArgHistoryMaxAge         = "history-max-age"
+	ArgHiddenColumns         = "hidden-columns"
+	ArgColumnWidths          = "column-widths"
+	ArgMaxColumnWidth        = "max-column-width"
+	ArgJsonFormat            = "json-format"
+	ArgTimestampFormat       = "timestamp-format"
+	ArgTimezone              = "timezone"
 )
 
 // metaquery mode arguments
@@ -0,0 +1,4 @@
+package constants
+
+// DefaultControlCacheTtl is the default expiration (TTL) in seconds of cached control results
+const DefaultControlCacheTtl = 3600
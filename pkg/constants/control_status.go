@@ -6,4 +6,9 @@ const (
 	ControlSkip  = "skip"
 	ControlInfo  = "info"
 	ControlError = "error"
+	// ControlSuppressed is the status of an alarm or error result which is suppressed by a baseline
+	ControlSuppressed = "suppressed"
 )
+
+// ControlRunErrorTimeout is the error reason of a control run whose query timed out
+const ControlRunErrorTimeout = "timeout"
@@ -59,6 +59,11 @@ const (
 	CommandTableSettingsCacheClearTimeKey = "cache_clear_time"
 
 	CommandTableScanMetadata = "scan_metadata"
+
+	// CheckResultsSchema is the schema containing the stored results of check runs
+	CheckResultsSchema = "steampipe_check"
+	CheckRunTable      = "steampipe_check_run"
+	CheckResultTable   = "steampipe_check_result"
 )
 
 // Functions :: a list of SQLFunc objects that are installed in the db 'internal' schema startup
@@ -83,6 +88,7 @@ end;
 var ReservedConnectionNames = []string{
 	"public",
 	FunctionSchema,
+	CheckResultsSchema,
 }
 
 // introspection table names
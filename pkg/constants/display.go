@@ -9,6 +9,23 @@ const (
 
 	MaxColumnWidth = 1024
 
+	// JsonFormatCompact and JsonFormatPretty are the supported renderings of json column values
+	JsonFormatCompact = "compact"
+	JsonFormatPretty  = "pretty"
+
+	// TimestampFormat is the default format used to display timestamp column values
+	TimestampFormat = "2006-01-02 15:04:05"
+	// TimestampTzFormat is the default format used to display timestamptz column values
+	// when a display timezone or timestamp format is configured
+	TimestampTzFormat = "2006-01-02 15:04:05 -07:00"
+
 	// NullString is the string which is displayed for null column values
 	NullString = "<null>"
+
+	// JSONLMetadataKey is the key of the trailing timing record written by the jsonl output
+	JSONLMetadataKey = "_metadata"
+
+	// ColumnarRowGroupSize is the number of rows buffered before a record batch (row group)
+	// is written for the parquet and arrow output formats
+	ColumnarRowGroupSize = 10000
 )
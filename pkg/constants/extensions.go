@@ -15,6 +15,10 @@ const (
 	TextExtension          = ".txt"
 	SnapshotExtension      = ".sps"
 	TokenExtension         = ".sptt"
+	ParquetExtension       = ".parquet"
+	ArrowExtension         = ".arrow"
+	SqliteExtension        = ".sqlite"
+	DuckDBExtension        = ".duckdb"
 )
 
 var YamlExtensions = []string{".yml", ".yaml"}
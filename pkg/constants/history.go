@@ -2,6 +2,7 @@ package constants
 
 // Constants for History
 const (
-	HistoryFile = "history.json" // File to store historical data
-	HistorySize = 500            // Number of historical records to store
+	HistoryFile        = "history.json" // File to store historical data
+	HistorySize        = 500            // Default number of historical records to store
+	HistoryDisplaySize = 50             // Number of historical records to display in the .history metaquery
 )
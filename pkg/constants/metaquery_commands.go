@@ -22,6 +22,12 @@ const (
 	CmdCache            = ".cache"              // cache control
 	CmdCacheTtl         = ".cache_ttl"          // set cache ttl
 	CmdAutoComplete     = ".autocomplete"       // enable or disable auto complete
+	CmdExplain          = ".explain"            // show the query plan
+	CmdAnalyze          = ".analyze"            // execute the query and show the query plan with actual timings
+	CmdHistory          = ".history"            // search the query history
+	CmdColumns          = ".columns"            // show or set column display formatting
+	CmdSave             = ".save"               // save the last query as a snippet
+	CmdSnippets         = ".snippets"           // list saved snippets
 )
 
 // ArgFromMetaquery converts a metaquery of form '.header' into the config argument used to set the mode, i.e. 'header'
@@ -3,6 +3,7 @@ package constants
 const (
 	OutputFormatCSV           = "csv"
 	OutputFormatJSON          = "json"
+	OutputFormatJSONL         = "jsonl"
 	OutputFormatTable         = "table"
 	OutputFormatLine          = "line"
 	OutputFormatNone          = "none"
@@ -10,4 +11,9 @@ const (
 	OutputFormatBrief         = "brief"
 	OutputFormatSnapshot      = "snapshot"
 	OutputFormatSnapshotShort = "sps"
+	OutputFormatParquet       = "parquet"
+	OutputFormatArrow         = "arrow"
+	OutputFormatSqlite        = "sqlite"
+	OutputFormatDuckDB        = "duckdb"
+	OutputFormatSarif         = "sarif"
 )
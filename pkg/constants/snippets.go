@@ -0,0 +1,6 @@
+package constants
+
+// Constants for query snippets
+const (
+	SnippetsFile = "snippets.json" // File to store saved query snippets
+)
@@ -31,20 +31,23 @@ type ControlColorSchemaDefinition struct {
 	CountGraphInfo       string
 	CountGraphOK         string
 	CountGraphSkip       string
+	CountGraphSuppressed string
 	CountGraphBracket    string
 
 	// results
-	StatusAlarm string
-	StatusError string
-	StatusSkip  string
-	StatusInfo  string
-	StatusOK    string
-	StatusColon string
-	ReasonAlarm string
-	ReasonError string
-	ReasonSkip  string
-	ReasonInfo  string
-	ReasonOK    string
+	StatusAlarm      string
+	StatusError      string
+	StatusSkip       string
+	StatusInfo       string
+	StatusOK         string
+	StatusColon      string
+	StatusSuppressed string
+	ReasonAlarm      string
+	ReasonError      string
+	ReasonSkip       string
+	ReasonInfo       string
+	ReasonOK         string
+	ReasonSuppressed string
 
 	Spacer   string
 	Indent   string
@@ -67,6 +70,7 @@ type ControlColorScheme struct {
 	CountGraphInfo       colorFunc
 	CountGraphOK         colorFunc
 	CountGraphSkip       colorFunc
+	CountGraphSuppressed colorFunc
 	CountGraphBracket    colorFunc
 	StatusAlarm          colorFunc
 	StatusError          colorFunc
@@ -74,11 +78,13 @@ type ControlColorScheme struct {
 	StatusInfo           colorFunc
 	StatusOK             colorFunc
 	StatusColon          colorFunc
+	StatusSuppressed     colorFunc
 	ReasonAlarm          colorFunc
 	ReasonError          colorFunc
 	ReasonSkip           colorFunc
 	ReasonInfo           colorFunc
 	ReasonOK             colorFunc
+	ReasonSuppressed     colorFunc
 	Spacer               colorFunc
 	Indent               colorFunc
 
@@ -143,25 +149,28 @@ func (c *ControlColorScheme) Initialise(def *ControlColorSchemaDefinition) error
 	}
 	// populate the color maps
 	c.ReasonColors = map[string]colorFunc{
-		constants.ControlAlarm: c.ReasonAlarm,
-		constants.ControlSkip:  c.ReasonSkip,
-		constants.ControlInfo:  c.ReasonInfo,
-		constants.ControlError: c.ReasonError,
-		constants.ControlOk:    c.ReasonOK,
+		constants.ControlAlarm:      c.ReasonAlarm,
+		constants.ControlSkip:       c.ReasonSkip,
+		constants.ControlInfo:       c.ReasonInfo,
+		constants.ControlError:      c.ReasonError,
+		constants.ControlOk:         c.ReasonOK,
+		constants.ControlSuppressed: c.ReasonSuppressed,
 	}
 	c.StatusColors = map[string]colorFunc{
-		constants.ControlAlarm: c.StatusAlarm,
-		constants.ControlSkip:  c.StatusSkip,
-		constants.ControlInfo:  c.StatusInfo,
-		constants.ControlError: c.StatusError,
-		constants.ControlOk:    c.StatusOK,
+		constants.ControlAlarm:      c.StatusAlarm,
+		constants.ControlSkip:       c.StatusSkip,
+		constants.ControlInfo:       c.StatusInfo,
+		constants.ControlError:      c.StatusError,
+		constants.ControlOk:         c.StatusOK,
+		constants.ControlSuppressed: c.StatusSuppressed,
 	}
 	c.GraphColors = map[string]colorFunc{
-		constants.ControlAlarm: c.CountGraphAlarm,
-		constants.ControlSkip:  c.CountGraphSkip,
-		constants.ControlInfo:  c.CountGraphInfo,
-		constants.ControlError: c.CountGraphError,
-		constants.ControlOk:    c.CountGraphOK,
+		constants.ControlAlarm:      c.CountGraphAlarm,
+		constants.ControlSkip:       c.CountGraphSkip,
+		constants.ControlInfo:       c.CountGraphInfo,
+		constants.ControlError:      c.CountGraphError,
+		constants.ControlOk:         c.CountGraphOK,
+		constants.ControlSuppressed: c.CountGraphSuppressed,
 	}
 
 	c.UseColor = def.UseColor
@@ -185,16 +194,19 @@ var ColorSchemes = map[string]*ControlColorSchemaDefinition{
 		CountGraphInfo:       "bright-cyan",
 		CountGraphOK:         "bright-green",
 		CountGraphSkip:       "gray3",
+		CountGraphSuppressed: "gray3",
 		CountGraphBracket:    "gray2",
 		StatusAlarm:          "bold-bright-red",
 		StatusError:          "bold-bright-red",
 		StatusSkip:           "gray3",
+		StatusSuppressed:     "gray3",
 		StatusInfo:           "bright-cyan",
 		StatusOK:             "bright-green",
 		StatusColon:          "gray1",
 		ReasonAlarm:          "bright-red",
 		ReasonError:          "bright-red",
 		ReasonSkip:           "gray3",
+		ReasonSuppressed:     "gray3",
 		ReasonInfo:           "bright-cyan",
 		ReasonOK:             "gray4",
 		Spacer:               "gray1",
@@ -217,16 +229,19 @@ var ColorSchemes = map[string]*ControlColorSchemaDefinition{
 		CountGraphInfo:       "bright-cyan",
 		CountGraphOK:         "bright-green",
 		CountGraphSkip:       "gray3",
+		CountGraphSuppressed: "gray3",
 		CountGraphBracket:    "gray4",
 		StatusAlarm:          "bold-bright-red",
 		StatusError:          "bold-bright-red",
 		StatusSkip:           "gray3",
+		StatusSuppressed:     "gray3",
 		StatusInfo:           "bright-cyan",
 		StatusOK:             "bright-green",
 		StatusColon:          "gray5",
 		ReasonAlarm:          "bright-red",
 		ReasonError:          "bright-red",
 		ReasonSkip:           "gray3",
+		ReasonSuppressed:     "gray3",
 		ReasonInfo:           "bright-cyan",
 		ReasonOK:             "gray2",
 		Spacer:               "gray5",
@@ -37,9 +37,15 @@ func NewControlRenderer(run *controlexecute.ControlRun, parent *GroupRenderer) *
 // are we the last child of our parent?
 // this affects the tree rendering
 func (r ControlRenderer) isLastChild() bool {
-	if r.parent.group == nil || r.parent.group.GroupItem == nil {
+	if r.parent.group == nil {
 		return true
 	}
+	if r.parent.group.GroupItem == nil {
+		// the group does not correspond to a benchmark - controls are rendered in the order they were added,
+		// followed by any child groups
+		runs := r.parent.group.ControlRuns
+		return len(r.parent.group.Groups) == 0 && len(runs) > 0 && runs[len(runs)-1] == r.run
+	}
 	siblings := r.parent.group.GroupItem.GetChildren()
 	return r.run.Control.Name() == siblings[len(siblings)-1].Name()
 }
@@ -75,7 +81,11 @@ func (r ControlRenderer) postResultIndent() string {
 func (r ControlRenderer) Render() string {
 	var controlStrings []string
 	// use group heading renderer to render the control title and counts
-	controlHeadingRenderer := NewGroupHeadingRenderer(typehelpers.SafeString(r.run.Control.Title),
+	title := typehelpers.SafeString(r.run.Control.Title)
+	if r.run.Cached {
+		title += " (cached)"
+	}
+	controlHeadingRenderer := NewGroupHeadingRenderer(title,
 		r.run.Summary.FailedCount(),
 		r.run.Summary.TotalCount(),
 		r.maxFailedControls,
@@ -95,6 +105,13 @@ func (r ControlRenderer) Render() string {
 		// newline after control heading
 		formattedPreResultIndent)
 
+	// if the results were compared with a previous run, render the new and resolved alarms
+	if r.run.Delta != nil && r.run.Delta.HasChanges() {
+		deltaRenderer := NewControlDeltaRenderer(r.run.Delta, r.width, r.parentIndent())
+		controlStrings = append(controlStrings, deltaRenderer.Render()...)
+		controlStrings = append(controlStrings, formattedPreResultIndent)
+	}
+
 	// if the control is in error, render an error
 	if r.run.GetError() != nil {
 		errorRenderer := NewErrorRenderer(r.run.GetError(), r.width, r.parentIndent())
@@ -128,5 +145,11 @@ func (r ControlRenderer) Render() string {
 		}
 	}
 
+	// if the control has failed, render the remediation and references
+	if remediationStrings := NewControlRemediationRenderer(r.run, r.width, r.parentIndent()).Render(); len(remediationStrings) > 0 {
+		controlStrings = append(controlStrings, remediationStrings...)
+		controlStrings = append(controlStrings, formattedPostResultIndent)
+	}
+
 	return strings.Join(controlStrings, "\n")
 }
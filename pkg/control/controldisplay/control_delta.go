@@ -0,0 +1,47 @@
+package controldisplay
+
+import (
+	"fmt"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+)
+
+type ControlDeltaRenderer struct {
+	delta *controlexecute.ControlDelta
+
+	// screen width
+	width  int
+	indent string
+}
+
+func NewControlDeltaRenderer(delta *controlexecute.ControlDelta, width int, indent string) *ControlDeltaRenderer {
+	return &ControlDeltaRenderer{
+		delta:  delta,
+		width:  width,
+		indent: indent,
+	}
+}
+
+// Render returns a line for each new and resolved alarm of the control
+func (r ControlDeltaRenderer) Render() []string {
+	var lines []string
+	for _, result := range r.delta.New {
+		lines = append(lines, r.renderResult("+ NEW", ControlColors.StatusAlarm, ControlColors.ReasonAlarm, result))
+	}
+	for _, result := range r.delta.Resolved {
+		lines = append(lines, r.renderResult("- RESOLVED", ControlColors.StatusOK, ControlColors.ReasonOK, result))
+	}
+	return lines
+}
+
+func (r ControlDeltaRenderer) renderResult(change string, changeColor, reasonColor colorFunc, result *controlexecute.DeltaResult) string {
+	formattedIndent := fmt.Sprintf("%s", ControlColors.Indent(r.indent))
+	changeString := fmt.Sprintf("%s%s ", changeColor(fmt.Sprintf("%-10s", change)), ControlColors.StatusColon(":"))
+
+	// figure out how much width we have available for the reason
+	availableWidth := r.width - helpers.PrintableLength(formattedIndent) - helpers.PrintableLength(changeString)
+	reasonString := fmt.Sprintf("%s", reasonColor(helpers.TruncateString(result.Reason, availableWidth)))
+
+	return fmt.Sprintf("%s%s%s", formattedIndent, changeString, reasonString)
+}
@@ -0,0 +1,55 @@
+package controldisplay
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+)
+
+type ControlRemediationRenderer struct {
+	run *controlexecute.ControlRun
+
+	// screen width
+	width  int
+	indent string
+}
+
+func NewControlRemediationRenderer(run *controlexecute.ControlRun, width int, indent string) *ControlRemediationRenderer {
+	return &ControlRemediationRenderer{
+		run:    run,
+		width:  width,
+		indent: indent,
+	}
+}
+
+// Render returns the remediation for the alarm and error results of the control, followed by the control references
+// if the remediation references the result row columns, each distinct rendered remediation is shown
+func (r ControlRemediationRenderer) Render() []string {
+	var lines []string
+	for _, remediation := range r.run.FailedRemediations() {
+		lines = append(lines, r.renderLabel("Remediation"))
+		for _, line := range strings.Split(strings.TrimSpace(remediation), "\n") {
+			lines = append(lines, r.renderLine("  "+line))
+		}
+	}
+	if len(r.run.References) > 0 && r.run.Summary.FailedCount() > 0 {
+		lines = append(lines, r.renderLabel("References"))
+		for _, reference := range r.run.References {
+			lines = append(lines, r.renderLine("  "+reference))
+		}
+	}
+	return lines
+}
+
+func (r ControlRemediationRenderer) renderLabel(label string) string {
+	formattedIndent := fmt.Sprintf("%s", ControlColors.Indent(r.indent))
+	return fmt.Sprintf("%s%s%s", formattedIndent, ControlColors.StatusInfo(label), ControlColors.StatusColon(":"))
+}
+
+func (r ControlRemediationRenderer) renderLine(line string) string {
+	formattedIndent := fmt.Sprintf("%s", ControlColors.Indent(r.indent))
+	availableWidth := r.width - helpers.PrintableLength(formattedIndent)
+	return fmt.Sprintf("%s%s", formattedIndent, ControlColors.ReasonInfo(helpers.TruncateString(line, availableWidth)))
+}
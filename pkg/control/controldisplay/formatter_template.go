@@ -80,7 +80,7 @@ func (tf TemplateFormatter) Format(ctx context.Context, tree *controlexecute.Exe
 		}
 	}()
 
-	// tactical - for json, prettify the output
+	// tactical - for json based formats, prettify the output
 	if tf.shouldPrettify() {
 		return utils.PrettifyJsonFromReader(reader)
 	}
@@ -104,5 +104,5 @@ func (tf TemplateFormatter) Alias() string {
 }
 
 func (tf TemplateFormatter) shouldPrettify() bool {
-	return tf.Name() == constants.OutputFormatJSON
+	return tf.Name() == constants.OutputFormatJSON || tf.Name() == constants.OutputFormatSarif
 }
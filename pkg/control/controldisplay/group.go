@@ -125,6 +125,8 @@ func (r GroupRenderer) Render() string {
 		r.maxTotalControls,
 		r.width,
 		r.headingIndent())
+	// set the score on the heading renderer (this is only set if scoring is enabled)
+	groupHeadingRenderer.score = r.group.Summary.Score
 
 	// render this group header
 	tableStrings := append([]string{},
@@ -155,6 +157,10 @@ func (r GroupRenderer) renderRootResultGroup() string {
 
 // render the children of this group, in the order they are specified in the hcl
 func (r GroupRenderer) renderChildren() []string {
+	// groups which do not correspond to a benchmark (i.e. if the results are grouped) have no hcl order
+	if r.group.GroupItem == nil {
+		return r.renderUnorderedChildren()
+	}
 	children := r.group.GroupItem.GetChildren()
 	var childStrings []string
 
@@ -175,3 +181,17 @@ func (r GroupRenderer) renderChildren() []string {
 
 	return childStrings
 }
+
+// render the control runs and then the child groups of this group, in the order they were added to the group
+func (r GroupRenderer) renderUnorderedChildren() []string {
+	var childStrings []string
+	for _, run := range r.group.ControlRuns {
+		controlRenderer := NewControlRenderer(run, &r)
+		childStrings = append(childStrings, controlRenderer.Render())
+	}
+	for _, childGroup := range r.group.Groups {
+		groupRenderer := NewGroupRenderer(childGroup, &r, r.maxFailedControls, r.maxTotalControls, r.resultTree, r.width)
+		childStrings = append(childStrings, groupRenderer.Render())
+	}
+	return childStrings
+}
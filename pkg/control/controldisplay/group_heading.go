@@ -19,6 +19,8 @@ type GroupHeadingRenderer struct {
 	// screen width
 	width  int
 	indent string
+	// the weighted score of the group (if scoring is enabled)
+	score *float64
 }
 
 func NewGroupHeadingRenderer(title string, failed, total, maxFailed, maxTotal, width int, indent string) *GroupHeadingRenderer {
@@ -46,9 +48,12 @@ func (r GroupHeadingRenderer) Render() string {
 	indentWidth := helpers.PrintableLength(formattedIndent)
 
 	// for a dry run we do not display the counters or graph
-	var severityString, counterString, graphString string
+	var severityString, scoreString, counterString, graphString string
 	if !isDryRun {
 		severityString = NewSeverityRenderer(r.severity).Render()
+		if r.score != nil {
+			scoreString = fmt.Sprintf(" %s", scoreColor(*r.score)(formatScore(*r.score)))
+		}
 		counterString = NewCounterRenderer(
 			r.failedControls,
 			r.totalControls,
@@ -69,11 +74,12 @@ func (r GroupHeadingRenderer) Render() string {
 		).Render()
 	}
 	severityWidth := helpers.PrintableLength(severityString)
+	scoreWidth := helpers.PrintableLength(scoreString)
 	counterWidth := helpers.PrintableLength(counterString)
 	graphWidth := helpers.PrintableLength(graphString)
 
 	// figure out how much width we have available for the title
-	availableWidth := r.width - counterWidth - graphWidth - severityWidth - indentWidth
+	availableWidth := r.width - counterWidth - graphWidth - severityWidth - scoreWidth - indentWidth
 
 	// now availableWidth is all we have - if it is not enough we need to truncate the title
 	titleString := NewGroupTitleRenderer(r.title, availableWidth).Render()
@@ -87,6 +93,6 @@ func (r GroupHeadingRenderer) Render() string {
 	}
 
 	// now put these all together
-	str := fmt.Sprintf("%s%s%s%s%s%s", formattedIndent, titleString, spacerString, severityString, counterString, graphString)
+	str := fmt.Sprintf("%s%s%s%s%s%s%s", formattedIndent, titleString, spacerString, severityString, scoreString, counterString, graphString)
 	return str
 }
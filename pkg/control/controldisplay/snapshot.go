@@ -18,7 +18,9 @@ func executionTreeToSnapshot(e *controlexecute.ExecutionTree) (*dashboardtypes.S
 	var checkRun *dashboardexecute.CheckRun
 
 	// get root benchmark/control
-	switch root := e.Root.Children[0].(type) {
+	// (if the results have been grouped, the snapshot still reflects the benchmark hierarchy)
+	benchmarkRoot := e.BenchmarkRoot()
+	switch root := benchmarkRoot.Children[0].(type) {
 	case *controlexecute.ResultGroup:
 		var ok bool
 		dashboardNode, ok = root.GroupItem.(modconfig.DashboardLeafNode)
@@ -30,7 +32,7 @@ func executionTreeToSnapshot(e *controlexecute.ExecutionTree) (*dashboardtypes.S
 	}
 
 	// TACTICAL create a check run to wrap the execution tree
-	checkRun = &dashboardexecute.CheckRun{Root: e.Root.Children[0]}
+	checkRun = &dashboardexecute.CheckRun{Root: benchmarkRoot.Children[0]}
 	checkRun.DashboardTreeRunImpl = dashboardexecute.NewDashboardTreeRunImpl(dashboardNode, nil, checkRun, nil)
 
 	// populate the panels
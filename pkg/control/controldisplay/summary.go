@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/control/controlexecute"
 )
 
@@ -49,7 +50,14 @@ func (r SummaryRenderer) Render() string {
 	alarmStatusRow := NewSummaryStatusRowRenderer(r.resultTree, availableWidth, "alarm").Render()
 	errorStatusRow := NewSummaryStatusRowRenderer(r.resultTree, availableWidth, "error").Render()
 
-	titleLine := fmt.Sprintf("%s\n", ControlColors.GroupTitle("Summary"))
+	title := "Summary"
+	if r.resultTree.ResultFilter != nil {
+		title = fmt.Sprintf("Summary (filtered by %s)", r.resultTree.ResultFilter)
+	}
+	if r.resultTree.GroupBy != nil {
+		title = fmt.Sprintf("%s (grouped by %s)", title, r.resultTree.GroupBy)
+	}
+	titleLine := fmt.Sprintf("%s\n", ControlColors.GroupTitle(title))
 
 	// build the summary
 	var summaryLines = []string{
@@ -61,6 +69,25 @@ func (r SummaryRenderer) Render() string {
 		alarmStatusRow,
 		errorStatusRow,
 	}
+	// only show suppressed results if a baseline was used
+	if r.resultTree.Root.Summary.Status.Suppressed > 0 {
+		summaryLines = append(summaryLines, NewSummaryStatusRowRenderer(r.resultTree, availableWidth, constants.ControlSuppressed).Render())
+	}
+	// if the results were compared with a previous run, add the delta block
+	if r.resultTree.Delta != nil {
+		summaryLines = append(summaryLines, "") // blank line
+		summaryLines = append(summaryLines, NewSummaryDeltaRenderer(r.resultTree.Delta, availableWidth).Render()...)
+	}
+	// if a failure threshold was set, add the threshold block
+	if r.resultTree.ThresholdResult != nil {
+		summaryLines = append(summaryLines, "") // blank line
+		summaryLines = append(summaryLines, NewSummaryThresholdRenderer(r.resultTree.ThresholdResult, availableWidth).Render()...)
+	}
+	// if scoring is enabled, add the score block
+	if score := r.resultTree.Root.Summary.Score; score != nil {
+		summaryLines = append(summaryLines, "") // blank line
+		summaryLines = append(summaryLines, NewSummaryScoreRenderer(*score, availableWidth).Render()...)
+	}
 	// if there is a severity block, add it
 	if len(severityRows) > 0 {
 		summaryLines = append(summaryLines, "") // blank line
@@ -0,0 +1,44 @@
+package controldisplay
+
+import (
+	"fmt"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+type SummaryDeltaRenderer struct {
+	delta *controlexecute.DeltaSummary
+	width int
+}
+
+func NewSummaryDeltaRenderer(delta *controlexecute.DeltaSummary, width int) *SummaryDeltaRenderer {
+	return &SummaryDeltaRenderer{
+		delta: delta,
+		width: width,
+	}
+}
+
+// Render returns the lines of the delta block of the summary - the number of new, resolved and unchanged alarms
+func (r SummaryDeltaRenderer) Render() []string {
+	titleLine := fmt.Sprintf("%s %s", ControlColors.GroupTitle("Changes since"), ControlColors.GroupTitle(r.delta.ComparedTo))
+	return []string{
+		titleLine,
+		"", // blank line
+		r.renderRow("NEW", r.delta.New, ControlColors.StatusAlarm),
+		r.renderRow("RESOLVED", r.delta.Resolved, ControlColors.StatusOK),
+		r.renderRow("UNCHANGED", r.delta.Unchanged, ControlColors.StatusSkip),
+	}
+}
+
+func (r SummaryDeltaRenderer) renderRow(label string, count int, cf colorFunc) string {
+	labelString := fmt.Sprintf("%s ", cf(label))
+	countString := fmt.Sprintf("%s", cf(message.NewPrinter(language.English).Sprintf("%d", count)))
+
+	spaceAvailableForSpacer := r.width - (helpers.PrintableLength(labelString) + helpers.PrintableLength(countString))
+	spacer := NewSpacerRenderer(spaceAvailableForSpacer)
+
+	return fmt.Sprintf("%s%s%s", labelString, spacer.Render(), countString)
+}
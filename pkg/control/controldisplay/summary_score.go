@@ -0,0 +1,50 @@
+package controldisplay
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/turbot/go-kit/helpers"
+)
+
+type SummaryScoreRenderer struct {
+	score float64
+	width int
+}
+
+func NewSummaryScoreRenderer(score float64, width int) *SummaryScoreRenderer {
+	return &SummaryScoreRenderer{
+		score: score,
+		width: width,
+	}
+}
+
+// Render returns the lines of the score block of the summary - the weighted compliance score of the run
+func (r SummaryScoreRenderer) Render() []string {
+	titleLine := ControlColors.GroupTitle("Score").String()
+
+	labelString := fmt.Sprintf("%s ", ControlColors.StatusOK("COMPLIANCE"))
+	scoreString := scoreColor(r.score)(formatScore(r.score)).String()
+
+	spaceAvailableForSpacer := r.width - (helpers.PrintableLength(labelString) + helpers.PrintableLength(scoreString))
+	spacer := NewSpacerRenderer(spaceAvailableForSpacer)
+
+	return []string{
+		titleLine,
+		"", // blank line
+		fmt.Sprintf("%s%s%s", labelString, spacer.Render(), scoreString),
+	}
+}
+
+// formatScore formats a score as a percentage, e.g. 87.5%
+func formatScore(score float64) string {
+	return fmt.Sprintf("%s%%", strconv.FormatFloat(score, 'f', -1, 64))
+}
+
+// scoreColor returns the color function for a score - a score of 100 is shown as passed
+func scoreColor(score float64) colorFunc {
+	if score >= 100 {
+		return ControlColors.CountTotalAllPassed
+	}
+	return ControlColors.CountFail
+}
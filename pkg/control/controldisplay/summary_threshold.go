@@ -0,0 +1,47 @@
+package controldisplay
+
+import (
+	"fmt"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+)
+
+type SummaryThresholdRenderer struct {
+	result *controlexecute.ThresholdResult
+	width  int
+}
+
+func NewSummaryThresholdRenderer(result *controlexecute.ThresholdResult, width int) *SummaryThresholdRenderer {
+	return &SummaryThresholdRenderer{
+		result: result,
+		width:  width,
+	}
+}
+
+// Render returns the lines of the failure threshold block of the summary - the threshold,
+// the number of alarms counting towards it and whether it was exceeded
+func (r SummaryThresholdRenderer) Render() []string {
+	titleLine := fmt.Sprintf("%s %s", ControlColors.GroupTitle("Failure threshold"), ControlColors.GroupTitle(fmt.Sprintf("(%s)", r.result.Threshold)))
+
+	statusColor := ControlColors.CountTotalAllPassed
+	status := "PASSED"
+	if r.result.Exceeded {
+		statusColor = ControlColors.CountFail
+		status = "FAILED"
+	}
+	labelString := fmt.Sprintf("%s ", statusColor(status))
+	countString := fmt.Sprintf("%s%s%s",
+		statusColor(r.result.Alarms),
+		ControlColors.CountDivider("/"),
+		ControlColors.CountTotal(r.result.Threshold.MaxAlarms))
+
+	spaceAvailableForSpacer := r.width - (helpers.PrintableLength(labelString) + helpers.PrintableLength(countString))
+	spacer := NewSpacerRenderer(spaceAvailableForSpacer)
+
+	return []string{
+		titleLine,
+		"", // blank line
+		fmt.Sprintf("%s%s%s", labelString, spacer.Render(), countString),
+	}
+}
@@ -0,0 +1,258 @@
+package controlexecute
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+// the supported formats of the suppression expiry date
+var suppressionExpiryFormats = []string{"2006-01-02", time.RFC3339}
+
+// Suppression identifies a known control result which has been accepted,
+// and should be reported as 'suppressed' rather than as an alarm or error
+type Suppression struct {
+	// the name of the control
+	Control string `json:"control"`
+	// the resource of the result
+	Resource string `json:"resource"`
+	// optional dimensions of the result - if set, these must all match the result dimensions
+	Dimensions map[string]string `json:"dimensions,omitempty"`
+	// the reason given for the result when the suppression was created
+	Reason string `json:"reason,omitempty"`
+	// optional expiry date (YYYY-MM-DD or RFC3339) - expired suppressions are not applied
+	Expires   string `json:"expires,omitempty"`
+	expiresAt time.Time
+}
+
+// IsExpired returns whether the suppression has an expiry date which has passed
+func (s *Suppression) IsExpired() bool {
+	return !s.expiresAt.IsZero() && time.Now().After(s.expiresAt)
+}
+
+// String returns a description of the suppression, used in warnings
+func (s *Suppression) String() string {
+	str := fmt.Sprintf("%s (%s)", s.Control, s.Resource)
+	if len(s.Dimensions) > 0 {
+		var dims []string
+		for k, v := range s.Dimensions {
+			dims = append(dims, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(dims)
+		str = fmt.Sprintf("%s [%s]", str, strings.Join(dims, ", "))
+	}
+	return str
+}
+
+func (s *Suppression) parseExpiry() error {
+	if s.Expires == "" {
+		return nil
+	}
+	for _, format := range suppressionExpiryFormats {
+		if t, err := time.Parse(format, s.Expires); err == nil {
+			s.expiresAt = t
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid expiry '%s' for suppression of control '%s', resource '%s' - expected a date in the format YYYY-MM-DD", s.Expires, s.Control, s.Resource)
+}
+
+// matches returns whether the suppression applies to the given control result row
+func (s *Suppression) matches(row *ResultRow) bool {
+	for key, value := range s.Dimensions {
+		if row.GetDimensionValue(key) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Baseline is a set of suppressions for the known results of a check run
+// a baseline file may either be a file written using '--write-baseline',
+// or the json output of a previous check run, in which case all alarm and error results are suppressed
+type Baseline struct {
+	Created      time.Time      `json:"created"`
+	Suppressions []*Suppression `json:"suppressions"`
+
+	// map of control name and resource to suppressions
+	suppressionMap map[string][]*Suppression
+	// expired suppressions which matched a result
+	expired     map[*Suppression]struct{}
+	expiredLock sync.Mutex
+}
+
+func NewBaseline() *Baseline {
+	return &Baseline{
+		Created:        time.Now(),
+		suppressionMap: make(map[string][]*Suppression),
+		expired:        make(map[*Suppression]struct{}),
+	}
+}
+
+// LoadBaseline loads a baseline from either a baseline file or the json output of a check run
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load baseline: %s", err.Error())
+	}
+	// first determine which kind of file this is
+	var contents map[string]json.RawMessage
+	if err := json.Unmarshal(data, &contents); err != nil {
+		return nil, fmt.Errorf("failed to load baseline '%s': %s", path, err.Error())
+	}
+
+	b := NewBaseline()
+	if _, ok := contents["suppressions"]; ok {
+		if err := json.Unmarshal(data, b); err != nil {
+			return nil, fmt.Errorf("failed to load baseline '%s': %s", path, err.Error())
+		}
+	} else {
+		var root checkOutputGroup
+		if err := json.Unmarshal(data, &root); err != nil {
+			return nil, fmt.Errorf("failed to load baseline '%s': %s", path, err.Error())
+		}
+		b.addCheckOutput(&root)
+	}
+
+	for _, s := range b.Suppressions {
+		if err := s.parseExpiry(); err != nil {
+			return nil, err
+		}
+		b.indexSuppression(s)
+	}
+	return b, nil
+}
+
+// Suppresses returns whether the given alarm or error result row is suppressed by the baseline
+func (b *Baseline) Suppresses(row *ResultRow) bool {
+	if row.Status != constants.ControlAlarm && row.Status != constants.ControlError {
+		return false
+	}
+	s := b.find(row)
+	if s == nil {
+		return false
+	}
+	if s.IsExpired() {
+		b.expiredLock.Lock()
+		b.expired[s] = struct{}{}
+		b.expiredLock.Unlock()
+		return false
+	}
+	return true
+}
+
+// ExpiredSuppressions returns the expired suppressions which matched a result
+// (and so were not applied)
+func (b *Baseline) ExpiredSuppressions() []*Suppression {
+	b.expiredLock.Lock()
+	defer b.expiredLock.Unlock()
+
+	res := make([]*Suppression, 0, len(b.expired))
+	for s := range b.expired {
+		res = append(res, s)
+	}
+	sortSuppressions(res)
+	return res
+}
+
+// AddExecutionTree adds a suppression for every alarm, error and suppressed result of the execution tree
+// if a previous baseline is passed, the expiry of any matching suppression is retained
+func (b *Baseline) AddExecutionTree(tree *ExecutionTree, previous *Baseline) {
+	for _, run := range tree.ControlRuns {
+		// include any results removed by the result filter
+		for _, row := range run.AllRows() {
+			switch row.Status {
+			case constants.ControlAlarm, constants.ControlError, constants.ControlSuppressed:
+			default:
+				continue
+			}
+			s := &Suppression{
+				Control:    run.FullName,
+				Resource:   row.Resource,
+				Dimensions: make(map[string]string, len(row.Dimensions)),
+				Reason:     row.Reason,
+			}
+			for _, d := range row.Dimensions {
+				s.Dimensions[d.Key] = d.Value
+			}
+			if previous != nil {
+				if existing := previous.find(row); existing != nil {
+					s.Expires = existing.Expires
+					s.expiresAt = existing.expiresAt
+				}
+			}
+			// the same control may be run more than once
+			if b.find(row) == nil {
+				b.Suppressions = append(b.Suppressions, s)
+				b.indexSuppression(s)
+			}
+		}
+	}
+}
+
+// Save writes the baseline to the given path
+func (b *Baseline) Save(path string) error {
+	sortSuppressions(b.Suppressions)
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// find returns the suppression matching the given row, whether or not it has expired
+func (b *Baseline) find(row *ResultRow) *Suppression {
+	// a suppression may use either the full or unqualified control name
+	for _, controlName := range []string{row.Run.FullName, row.Run.ControlId} {
+		for _, s := range b.suppressionMap[suppressionKey(controlName, row.Resource)] {
+			if s.matches(row) {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Baseline) indexSuppression(s *Suppression) {
+	key := suppressionKey(s.Control, s.Resource)
+	b.suppressionMap[key] = append(b.suppressionMap[key], s)
+}
+
+func suppressionKey(control, resource string) string {
+	return fmt.Sprintf("%s|%s", control, resource)
+}
+
+func sortSuppressions(suppressions []*Suppression) {
+	sort.Slice(suppressions, func(i, j int) bool {
+		if suppressions[i].Control != suppressions[j].Control {
+			return suppressions[i].Control < suppressions[j].Control
+		}
+		return suppressions[i].Resource < suppressions[j].Resource
+	})
+}
+
+func (b *Baseline) addCheckOutput(root *checkOutputGroup) {
+	for _, control := range root.allControls() {
+		for _, result := range control.Results {
+			if result.Status != constants.ControlAlarm && result.Status != constants.ControlError {
+				continue
+			}
+			s := &Suppression{
+				Control:    control.ControlId,
+				Resource:   result.Resource,
+				Dimensions: make(map[string]string, len(result.Dimensions)),
+				Reason:     result.Reason,
+			}
+			for _, d := range result.Dimensions {
+				s.Dimensions[d.Key] = d.Value
+			}
+			b.Suppressions = append(b.Suppressions, s)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package controlexecute
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+func TestBaselineSuppresses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	baselineFile := `{
+  "suppressions": [
+    {"control": "aws_compliance.control.s3_public", "resource": "bucket_a"},
+    {"control": "control.s3_logging", "resource": "bucket_b", "dimensions": {"region": "us-east-1"}},
+    {"control": "aws_compliance.control.s3_public", "resource": "bucket_c", "expires": "2001-01-01"}
+  ]
+}`
+	if err := os.WriteFile(path, []byte(baselineFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicRun := &ControlRun{FullName: "aws_compliance.control.s3_public", ControlId: "control.s3_public"}
+	loggingRun := &ControlRun{FullName: "aws_compliance.control.s3_logging", ControlId: "control.s3_logging"}
+	region := func(r string) []Dimension { return []Dimension{{Key: "region", Value: r}} }
+
+	cases := map[string]struct {
+		row      *ResultRow
+		expected bool
+	}{
+		"alarm matching resource": {
+			row:      &ResultRow{Run: publicRun, Resource: "bucket_a", Status: constants.ControlAlarm},
+			expected: true,
+		},
+		"ok matching resource": {
+			row:      &ResultRow{Run: publicRun, Resource: "bucket_a", Status: constants.ControlOk},
+			expected: false,
+		},
+		"alarm other resource": {
+			row:      &ResultRow{Run: publicRun, Resource: "bucket_b", Status: constants.ControlAlarm},
+			expected: false,
+		},
+		"error matching unqualified control and dimensions": {
+			row:      &ResultRow{Run: loggingRun, Resource: "bucket_b", Status: constants.ControlError, Dimensions: region("us-east-1")},
+			expected: true,
+		},
+		"alarm with different dimensions": {
+			row:      &ResultRow{Run: loggingRun, Resource: "bucket_b", Status: constants.ControlAlarm, Dimensions: region("eu-west-1")},
+			expected: false,
+		},
+		"alarm matching expired suppression": {
+			row:      &ResultRow{Run: publicRun, Resource: "bucket_c", Status: constants.ControlAlarm},
+			expected: false,
+		},
+	}
+	for name, c := range cases {
+		if actual := baseline.Suppresses(c.row); actual != c.expected {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, actual)
+		}
+	}
+
+	if expired := baseline.ExpiredSuppressions(); len(expired) != 1 || expired[0].Resource != "bucket_c" {
+		t.Errorf("expected 1 expired suppression for bucket_c, got %v", expired)
+	}
+}
+
+func TestLoadBaselineFromCheckOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "output.json")
+	checkOutput := `{
+  "group_id": "root_result_group",
+  "groups": [
+    {
+      "group_id": "benchmark.s3",
+      "groups": [],
+      "controls": [
+        {
+          "control_id": "control.s3_public",
+          "results": [
+            {"reason": "bucket_a is public", "resource": "bucket_a", "status": "alarm", "dimensions": [{"key": "region", "value": "us-east-1"}]},
+            {"reason": "bucket_b is private", "resource": "bucket_b", "status": "ok", "dimensions": []}
+          ]
+        }
+      ]
+    }
+  ],
+  "controls": null
+}`
+	if err := os.WriteFile(path, []byte(checkOutput), 0644); err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(baseline.Suppressions) != 1 {
+		t.Fatalf("expected 1 suppression, got %d", len(baseline.Suppressions))
+	}
+	s := baseline.Suppressions[0]
+	if s.Control != "control.s3_public" || s.Resource != "bucket_a" || s.Dimensions["region"] != "us-east-1" {
+		t.Errorf("unexpected suppression %s", s)
+	}
+}
+
+func TestLoadBaselineInvalidExpiry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	baselineFile := `{"suppressions": [{"control": "control.s3_public", "resource": "bucket_a", "expires": "next week"}]}`
+	if err := os.WriteFile(path, []byte(baselineFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBaseline(path); err == nil {
+		t.Errorf("expected an error loading a baseline with an invalid expiry")
+	}
+}
@@ -0,0 +1,29 @@
+package controlexecute
+
+// checkOutputGroup, checkOutputControl and checkOutputResult are the parts of the json output of a check run
+// which are read back when a previous run is used as a baseline or for comparison
+type checkOutputGroup struct {
+	Groups   []*checkOutputGroup   `json:"groups"`
+	Controls []*checkOutputControl `json:"controls"`
+}
+
+type checkOutputControl struct {
+	ControlId string               `json:"control_id"`
+	Results   []*checkOutputResult `json:"results"`
+}
+
+type checkOutputResult struct {
+	Reason     string      `json:"reason"`
+	Resource   string      `json:"resource"`
+	Status     string      `json:"status"`
+	Dimensions []Dimension `json:"dimensions"`
+}
+
+// allControls returns the controls of the group and all descendant groups
+func (g *checkOutputGroup) allControls() []*checkOutputControl {
+	res := append([]*checkOutputControl{}, g.Controls...)
+	for _, child := range g.Groups {
+		res = append(res, child.allControls()...)
+	}
+	return res
+}
@@ -0,0 +1,152 @@
+package controlexecute
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+// ControlCache is an on-disk cache of control results, used to avoid re-executing unchanged controls
+// results are keyed on the resolved control sql and args, the search path and the connection config
+type ControlCache struct {
+	dir string
+	ttl time.Duration
+	// hash of the connection config - if the connection config changes, all cached results are invalid
+	connectionHash string
+}
+
+func NewControlCache(dir string, ttl time.Duration, connections map[string]*modconfig.Connection) *ControlCache {
+	return &ControlCache{
+		dir:            dir,
+		ttl:            ttl,
+		connectionHash: connectionConfigHash(connections),
+	}
+}
+
+// controlCacheEntry is the cached result of a control query
+type controlCacheEntry struct {
+	Created time.Time          `json:"created"`
+	Rows    []*cachedResultRow `json:"rows"`
+}
+
+// cachedResultRow contains the result row properties which are populated from the control query
+// NOTE: the status is the status returned by the query, before any baseline suppression is applied
+type cachedResultRow struct {
+	Reason     string            `json:"reason"`
+	Resource   string            `json:"resource"`
+	Status     string            `json:"status"`
+	Dimensions []cachedDimension `json:"dimensions"`
+}
+
+type cachedDimension struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	SqlType string `json:"sql_type"`
+}
+
+func newCachedResultRow(row *ResultRow) *cachedResultRow {
+	res := &cachedResultRow{
+		Reason:     row.Reason,
+		Resource:   row.Resource,
+		Status:     row.Status,
+		Dimensions: make([]cachedDimension, len(row.Dimensions)),
+	}
+	for i, d := range row.Dimensions {
+		res.Dimensions[i] = cachedDimension{Key: d.Key, Value: d.Value, SqlType: d.SqlType}
+	}
+	return res
+}
+
+func (c *cachedResultRow) toResultRow(run *ControlRun) *ResultRow {
+	res := &ResultRow{
+		Reason:     c.Reason,
+		Resource:   c.Resource,
+		Status:     c.Status,
+		Dimensions: make([]Dimension, len(c.Dimensions)),
+		Run:        run,
+		Control:    run.Control,
+	}
+	for i, d := range c.Dimensions {
+		res.Dimensions[i] = Dimension{Key: d.Key, Value: d.Value, SqlType: d.SqlType}
+	}
+	return res
+}
+
+// key returns the cache key for the given control query
+func (c *ControlCache) key(query *modconfig.ResolvedQuery, searchPath []string) (string, error) {
+	argsJson, err := json.Marshal(query.Args)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	for _, s := range []string{query.ExecuteSQL, string(argsJson), strings.Join(searchPath, ","), c.connectionHash} {
+		hash.Write([]byte(s))
+		// separate the components so they cannot run together
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// get returns the cached result rows for the given key, if they exist and have not expired
+func (c *ControlCache) get(key string) ([]*cachedResultRow, bool) {
+	path := c.entryPath(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry controlCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		log.Printf("[WARN] failed to read control cache entry %s: %s", path, err.Error())
+		return nil, false
+	}
+	if time.Since(entry.Created) > c.ttl {
+		// the entry has expired - remove it
+		os.Remove(path)
+		return nil, false
+	}
+	return entry.Rows, true
+}
+
+// set writes the result rows for the given key to the cache
+func (c *ControlCache) set(key string, rows []*cachedResultRow) error {
+	data, err := json.Marshal(&controlCacheEntry{Created: time.Now(), Rows: rows})
+	if err != nil {
+		return err
+	}
+	// write to a temp file and rename, so a concurrent run never reads a partially written entry
+	path := c.entryPath(key)
+	tempPath := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tempPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, path)
+}
+
+func (c *ControlCache) entryPath(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// connectionConfigHash returns a hash of the connection config, which is included in the cache key
+func connectionConfigHash(connections map[string]*modconfig.Connection) string {
+	names := make([]string, 0, len(connections))
+	for name := range connections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		c := connections[name]
+		fmt.Fprintf(hash, "%s\x00%s\x00%s\x00%s\x00%s\x00", name, c.Plugin, c.Type, c.Config, strings.Join(c.ConnectionNames, ","))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
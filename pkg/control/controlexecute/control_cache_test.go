@@ -0,0 +1,76 @@
+package controlexecute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+func TestControlCacheGetSet(t *testing.T) {
+	connections := map[string]*modconfig.Connection{"aws": {Name: "aws", Plugin: "hub.steampipe.io/plugins/turbot/aws@latest"}}
+	cache := NewControlCache(t.TempDir(), time.Hour, connections)
+
+	key, err := cache.key(&modconfig.ResolvedQuery{ExecuteSQL: "select 1", Args: []any{"a"}}, []string{"aws"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.get(key); ok {
+		t.Fatalf("expected a cache miss before the results are cached")
+	}
+
+	rows := []*cachedResultRow{{Reason: "bucket_a is public", Resource: "bucket_a", Status: constants.ControlAlarm, Dimensions: []cachedDimension{{Key: "region", Value: "us-east-1"}}}}
+	if err := cache.set(key, rows); err != nil {
+		t.Fatal(err)
+	}
+	cached, ok := cache.get(key)
+	if !ok {
+		t.Fatalf("expected a cache hit after the results are cached")
+	}
+	if len(cached) != 1 || cached[0].Resource != "bucket_a" || cached[0].Dimensions[0].Value != "us-east-1" {
+		t.Errorf("unexpected cached rows %v", cached)
+	}
+
+	// an expired entry is not returned
+	expiredCache := NewControlCache(cache.dir, time.Nanosecond, connections)
+	time.Sleep(time.Millisecond)
+	if _, ok := expiredCache.get(key); ok {
+		t.Errorf("expected a cache miss for an expired entry")
+	}
+}
+
+func TestControlCacheKey(t *testing.T) {
+	connections := map[string]*modconfig.Connection{"aws": {Name: "aws", Plugin: "hub.steampipe.io/plugins/turbot/aws@latest"}}
+	changedConnections := map[string]*modconfig.Connection{"aws": {Name: "aws", Plugin: "hub.steampipe.io/plugins/turbot/aws@latest", Config: `regions = ["*"]`}}
+	query := &modconfig.ResolvedQuery{ExecuteSQL: "select 1", Args: []any{"a"}}
+	searchPath := []string{"aws"}
+
+	cache := NewControlCache(t.TempDir(), time.Hour, connections)
+	baseKey, err := cache.key(query, searchPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		connections map[string]*modconfig.Connection
+		query       *modconfig.ResolvedQuery
+		searchPath  []string
+		changed     bool
+	}{
+		"unchanged":           {connections, query, searchPath, false},
+		"changed sql":         {connections, &modconfig.ResolvedQuery{ExecuteSQL: "select 2", Args: []any{"a"}}, searchPath, true},
+		"changed args":        {connections, &modconfig.ResolvedQuery{ExecuteSQL: "select 1", Args: []any{"b"}}, searchPath, true},
+		"changed search path": {connections, query, []string{"aws", "gcp"}, true},
+		"changed connection":  {changedConnections, query, searchPath, true},
+	}
+	for name, c := range cases {
+		key, err := NewControlCache(cache.dir, time.Hour, c.connections).key(c.query, c.searchPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changed := key != baseKey; changed != c.changed {
+			t.Errorf("%s: expected key changed %v, got %v", name, c.changed, changed)
+		}
+	}
+}
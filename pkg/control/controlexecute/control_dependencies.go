@@ -0,0 +1,158 @@
+package controlexecute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardtypes"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+	"golang.org/x/sync/semaphore"
+)
+
+// resolveDependencies resolves the 'depends_on' controls of each control run to the runs of those controls,
+// and groups the runs which have dependencies by dependency level
+// dependencies on controls which are not included in the run are ignored
+func (e *ExecutionTree) resolveDependencies() error {
+	runsByName := make(map[string][]*ControlRun)
+	for _, run := range e.ControlRuns {
+		runsByName[run.Control.Name()] = append(runsByName[run.Control.Name()], run)
+	}
+	for _, run := range e.ControlRuns {
+		for _, name := range run.Control.DependsOnNames {
+			dependencies, ok := runsByName[name]
+			if !ok {
+				log.Printf("[TRACE] %s depends on %s, which is not included in the run - ignoring the dependency", run.Control.Name(), name)
+				continue
+			}
+			run.dependencies = append(run.dependencies, dependencies...)
+		}
+	}
+
+	// the level of a run is one greater than the highest level of its dependencies
+	resolved := make(map[*ControlRun]bool)
+	visiting := make(map[*ControlRun]bool)
+	var resolveLevel func(run *ControlRun) error
+	resolveLevel = func(run *ControlRun) error {
+		if resolved[run] {
+			return nil
+		}
+		if visiting[run] {
+			return fmt.Errorf("control %s has a circular dependency", run.Control.Name())
+		}
+		visiting[run] = true
+		for _, dependency := range run.dependencies {
+			if err := resolveLevel(dependency); err != nil {
+				return err
+			}
+			if dependency.dependencyLevel >= run.dependencyLevel {
+				run.dependencyLevel = dependency.dependencyLevel + 1
+			}
+		}
+		visiting[run] = false
+		resolved[run] = true
+		return nil
+	}
+
+	for _, run := range e.ControlRuns {
+		if err := resolveLevel(run); err != nil {
+			return err
+		}
+		if run.dependencyLevel == 0 {
+			continue
+		}
+		for len(e.dependentRuns) < run.dependencyLevel {
+			e.dependentRuns = append(e.dependentRuns, nil)
+		}
+		e.dependentRuns[run.dependencyLevel-1] = append(e.dependentRuns[run.dependencyLevel-1], run)
+	}
+	return nil
+}
+
+// executeDependentRuns executes the runs which depend on other runs, one dependency level at a time
+// a run is skipped if any of its dependencies has a result with a status in the skip_if list of the control
+func (e *ExecutionTree) executeDependentRuns(ctx context.Context, parallelismLock *semaphore.Weighted, maxParallelGoRoutines int64) {
+	for i, levelRuns := range e.dependentRuns {
+		// wait for the runs of the previous level to complete
+		if err := e.waitForActiveRunsToComplete(ctx, parallelismLock, maxParallelGoRoutines); err != nil {
+			log.Printf("[WARN] timed out waiting for active runs to complete")
+			// the remaining runs cannot be executed
+			for _, remainingRuns := range e.dependentRuns[i:] {
+				for _, run := range remainingRuns {
+					run.Tree.Progress.OnControlStart(ctx, run)
+					run.setError(ctx, ctx.Err())
+					run.complete(ctx)
+				}
+			}
+			return
+		}
+		parallelismLock.Release(maxParallelGoRoutines)
+
+		for _, run := range levelRuns {
+			if !error_helpers.IsContextCanceled(ctx) {
+				if reason := run.dependencySkipReason(); reason != "" {
+					run.skipForDependency(ctx, reason)
+					continue
+				}
+			}
+			startRun(ctx, run, parallelismLock, e.client)
+		}
+	}
+}
+
+// dependencySkipReason returns the reason for skipping the run, if any of its dependencies
+// has a result with a status in the skip_if list of the control
+func (r *ControlRun) dependencySkipReason() string {
+	skipIf := r.Control.GetSkipIf()
+	for _, dependency := range r.dependencies {
+		summary := dependency.GetStatusSummary()
+		var statuses []string
+		for _, status := range skipIf {
+			if statusCount(summary, status) > 0 {
+				statuses = append(statuses, status)
+			}
+		}
+		if len(statuses) > 0 {
+			return fmt.Sprintf("Skipped as dependency %s has %s results", dependency.ControlId, strings.Join(statuses, " and "))
+		}
+	}
+	return ""
+}
+
+// skipForDependency completes the run with a single skip result with the given reason
+func (r *ControlRun) skipForDependency(ctx context.Context, reason string) {
+	r.Tree.Progress.OnControlStart(ctx, r)
+
+	r.addResultRow(&ResultRow{
+		Reason:  reason,
+		Status:  constants.ControlSkip,
+		Run:     r,
+		Control: r.Control,
+	})
+	r.createdOrderedResultRows()
+	r.Data = r.Rows.ToLeafData(r.getDimensionSchema())
+	r.setRunStatus(ctx, dashboardtypes.RunComplete)
+	r.complete(ctx)
+}
+
+// statusCount returns the count of the given status in the summary
+func statusCount(summary *controlstatus.StatusSummary, status string) int {
+	switch status {
+	case constants.ControlOk:
+		return summary.Ok
+	case constants.ControlAlarm:
+		return summary.Alarm
+	case constants.ControlInfo:
+		return summary.Info
+	case constants.ControlSkip:
+		return summary.Skip
+	case constants.ControlError:
+		return summary.Error
+	case constants.ControlSuppressed:
+		return summary.Suppressed
+	}
+	return 0
+}
@@ -0,0 +1,115 @@
+package controlexecute
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardtypes"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+	"golang.org/x/sync/semaphore"
+)
+
+func newDependencyTestRun(name string, dependsOn ...string) *ControlRun {
+	control := &modconfig.Control{DependsOnNames: dependsOn}
+	control.FullName = name
+	return &ControlRun{Control: control, ControlId: name, Summary: &controlstatus.StatusSummary{}}
+}
+
+func TestResolveDependencies(t *testing.T) {
+	a := newDependencyTestRun("m.control.a")
+	b := newDependencyTestRun("m.control.b", "m.control.a")
+	c := newDependencyTestRun("m.control.c", "m.control.b", "m.control.a")
+	// a dependency which is not included in the run is ignored
+	d := newDependencyTestRun("m.control.d", "m.control.not_in_run")
+
+	tree := &ExecutionTree{ControlRuns: []*ControlRun{c, b, a, d}}
+	if err := tree.resolveDependencies(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedLevels := map[*ControlRun]int{a: 0, b: 1, c: 2, d: 0}
+	for run, expected := range expectedLevels {
+		if run.dependencyLevel != expected {
+			t.Errorf("%s: expected dependency level %d, got %d", run.ControlId, expected, run.dependencyLevel)
+		}
+	}
+	if len(tree.dependentRuns) != 2 || len(tree.dependentRuns[0]) != 1 || tree.dependentRuns[0][0] != b || len(tree.dependentRuns[1]) != 1 || tree.dependentRuns[1][0] != c {
+		t.Errorf("unexpected dependent runs: %v", tree.dependentRuns)
+	}
+}
+
+func TestResolveDependenciesCircular(t *testing.T) {
+	a := newDependencyTestRun("m.control.a", "m.control.b")
+	b := newDependencyTestRun("m.control.b", "m.control.a")
+
+	tree := &ExecutionTree{ControlRuns: []*ControlRun{a, b}}
+	if err := tree.resolveDependencies(); err == nil {
+		t.Errorf("expected a circular dependency error")
+	}
+}
+
+func TestDependencySkipReason(t *testing.T) {
+	dependency := newDependencyTestRun("m.control.a")
+	dependency.Summary.Alarm = 1
+
+	cases := map[string]struct {
+		skipIf       []string
+		expectedSkip bool
+	}{
+		"default skips on alarm": {skipIf: nil, expectedSkip: true},
+		"skip on error only":     {skipIf: []string{"error"}, expectedSkip: false},
+		"skip on ok or alarm":    {skipIf: []string{"ok", "alarm"}, expectedSkip: true},
+	}
+	for name, c := range cases {
+		run := newDependencyTestRun("m.control.b", "m.control.a")
+		run.Control.SkipIf = c.skipIf
+		run.dependencies = []*ControlRun{dependency}
+
+		reason := run.dependencySkipReason()
+		if (reason != "") != c.expectedSkip {
+			t.Errorf("Test: '%s' FAILED: expected skip %v, got reason '%s'", name, c.expectedSkip, reason)
+			continue
+		}
+		if c.expectedSkip && !strings.Contains(reason, "m.control.a has alarm results") {
+			t.Errorf("Test: '%s' FAILED: unexpected reason '%s'", name, reason)
+		}
+	}
+}
+
+func TestExecuteDependentRunsError(t *testing.T) {
+	root := &ResultGroup{Summary: NewGroupSummary(), updateLock: new(sync.Mutex)}
+	tree := &ExecutionTree{Root: root, Progress: controlstatus.NewControlProgress(2)}
+	var runs []*ControlRun
+	for _, name := range []string{"m.control.b", "m.control.c"} {
+		run := newDependencyTestRun(name)
+		run.Group = root
+		run.Tree = tree
+		run.doneChan = make(chan bool, 1)
+		runs = append(runs, run)
+	}
+	tree.dependentRuns = [][]*ControlRun{{runs[0]}, {runs[1]}}
+
+	// the active runs never complete, so waiting for them fails when the context times out
+	parallelismLock := semaphore.NewWeighted(1)
+	_ = parallelismLock.Acquire(context.Background(), 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	tree.executeDependentRuns(ctx, parallelismLock, 1)
+
+	for _, run := range runs {
+		if run.GetRunStatus() != dashboardtypes.RunError {
+			t.Errorf("%s: expected run status %s, got %s", run.ControlId, dashboardtypes.RunError, run.GetRunStatus())
+		}
+	}
+	// the errors are included in the group totals and the progress
+	if root.Summary.Status.Error != 2 {
+		t.Errorf("expected group error count 2, got %d", root.Summary.Status.Error)
+	}
+	if tree.Progress.Error != 2 || tree.Progress.Executing != 0 || tree.Progress.Pending != 0 {
+		t.Errorf("unexpected progress: error %d, executing %d, pending %d", tree.Progress.Error, tree.Progress.Executing, tree.Progress.Pending)
+	}
+}
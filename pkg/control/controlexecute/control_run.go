@@ -2,11 +2,15 @@ package controlexecute
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
+	"text/template"
 	"time"
 
+	"github.com/turbot/go-kit/helpers"
 	typehelpers "github.com/turbot/go-kit/types"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc"
 	"github.com/turbot/steampipe/pkg/constants"
@@ -31,6 +35,10 @@ type ControlRun struct {
 	Tags          map[string]string `json:"tags,omitempty"`
 	Display       string            `json:"display,omitempty"`
 	Type          string            `json:"display_type,omitempty"`
+	// remediation guidance in markdown - if this references result row columns, it is rendered for each row
+	Remediation string `json:"remediation,omitempty"`
+	// references for the control, e.g. urls or compliance section numbers
+	References []string `json:"references,omitempty"`
 
 	// this will be serialised under 'properties'
 	Severity string `json:"-"`
@@ -48,6 +56,10 @@ type ControlRun struct {
 
 	// the results in snapshot format
 	Data *dashboardtypes.LeafData `json:"data"`
+	// the change in alarms compared with a previous check run (if a comparison was requested)
+	Delta *ControlDelta `json:"delta,omitempty"`
+	// were the results served from the control cache
+	Cached bool `json:"cached,omitempty"`
 
 	// a list of distinct dimension keys from the results of this control
 	DimensionKeys []string `json:"-"`
@@ -60,6 +72,8 @@ type ControlRun struct {
 	Tree *ExecutionTree `json:"-"`
 	// save run error as string for JSON export
 	RunErrorString string `json:"error,omitempty"`
+	// the reason for the run error, if it has a distinct reason (e.g. 'timeout')
+	RunErrorReason string `json:"error_reason,omitempty"`
 	runError       error
 	// the query result stream
 	queryResult *queryresult.Result
@@ -67,6 +81,21 @@ type ControlRun struct {
 	stateLock   sync.Mutex
 	doneChan    chan bool
 	attempts    int
+	// the query timeout and number of retries - set on the control or inherited from a parent benchmark
+	timeout time.Duration
+	retries int
+	// the remediation template - only set if the remediation references result row columns
+	remediationTemplate *template.Template
+	// the control cache key and the rows to cache (if the control cache is enabled)
+	cacheKey  string
+	cacheRows []*cachedResultRow
+	// if a result filter has been applied, the result rows and summary before filtering
+	unfilteredRows    ResultRows
+	unfilteredSummary *controlstatus.StatusSummary
+	// the runs of the controls this control depends on, and the resulting dependency level
+	// (runs with no dependencies have level 0)
+	dependencies    []*ControlRun
+	dependencyLevel int
 }
 
 func NewControlRun(control *modconfig.Control, group *ResultGroup, executionTree *ExecutionTree) *ControlRun {
@@ -86,6 +115,8 @@ func NewControlRun(control *modconfig.Control, group *ResultGroup, executionTree
 		Tags:          control.GetTags(),
 		Display:       control.GetDisplay(),
 		Type:          control.GetType(),
+		Remediation:   typehelpers.SafeString(control.Remediation),
+		References:    control.References,
 
 		Severity:  typehelpers.SafeString(control.Severity),
 		Title:     typehelpers.SafeString(control.Title),
@@ -98,9 +129,73 @@ func NewControlRun(control *modconfig.Control, group *ResultGroup, executionTree
 		NodeType: modconfig.BlockTypeControl,
 		doneChan: make(chan bool, 1),
 	}
+	res.setTimeoutAndRetries()
+	res.setRemediationTemplate()
 	return res
 }
 
+// setRemediationTemplate parses the remediation if it references result row columns
+// (the remediation has already been validated when the control was decoded)
+func (r *ControlRun) setRemediationTemplate() {
+	if !strings.Contains(r.Remediation, "{{") {
+		return
+	}
+	t, err := template.New(r.FullName).Option("missingkey=zero").Parse(r.Remediation)
+	if err != nil {
+		log.Printf("[WARN] failed to parse remediation for %s: %s", r.FullName, err.Error())
+		return
+	}
+	r.remediationTemplate = t
+}
+
+// GetReferenceUrl returns the first reference which is a url, if any
+func (r *ControlRun) GetReferenceUrl() string {
+	for _, reference := range r.References {
+		if strings.HasPrefix(reference, "https://") || strings.HasPrefix(reference, "http://") {
+			return reference
+		}
+	}
+	return ""
+}
+
+// FailedRemediations returns the distinct remediations of the alarm and error results
+func (r *ControlRun) FailedRemediations() []string {
+	var res []string
+	for _, row := range r.Rows {
+		if row.Status != constants.ControlAlarm && row.Status != constants.ControlError {
+			continue
+		}
+		if remediation := row.GetRemediation(); remediation != "" && !helpers.StringSliceContains(res, remediation) {
+			res = append(res, remediation)
+		}
+	}
+	return res
+}
+
+// setTimeoutAndRetries sets the query timeout and number of retries from the control,
+// or if they are not set on the control, from the nearest parent benchmark which sets them
+func (r *ControlRun) setTimeoutAndRetries() {
+	timeout, retries := r.Control.Timeout, r.Control.Retries
+	for group := r.Group; group != nil && (timeout == nil || retries == nil); group = group.Parent {
+		benchmark, ok := group.GroupItem.(*modconfig.Benchmark)
+		if !ok {
+			continue
+		}
+		if timeout == nil {
+			timeout = benchmark.Timeout
+		}
+		if retries == nil {
+			retries = benchmark.Retries
+		}
+	}
+	if timeout != nil {
+		r.timeout = time.Duration(*timeout) * time.Second
+	}
+	if retries != nil {
+		r.retries = *retries
+	}
+}
+
 // GetControlId implements ControlRunStatusProvider
 func (r *ControlRun) GetControlId() string {
 	r.stateLock.Lock()
@@ -161,8 +256,13 @@ func (r *ControlRun) setError(ctx context.Context, err error) {
 	if err == nil {
 		return
 	}
-	if r.runError == context.DeadlineExceeded {
-		r.runError = fmt.Errorf("control execution timed out")
+	if errors.Is(err, context.DeadlineExceeded) {
+		r.RunErrorReason = constants.ControlRunErrorTimeout
+		if r.timeout > 0 {
+			r.runError = fmt.Errorf("control execution timed out after %s", r.timeout)
+		} else {
+			r.runError = fmt.Errorf("control execution timed out")
+		}
 	} else {
 		r.runError = error_helpers.TransformErrorToSteampipe(err)
 	}
@@ -191,90 +291,157 @@ func (r *ControlRun) execute(ctx context.Context, client db_common.Client) {
 
 	startTime := time.Now()
 
+	// set our status
+	r.RunStatus = dashboardtypes.RunRunning
+
+	// update the current running control in the Progress renderer
+	r.Tree.Progress.OnControlStart(ctx, r)
+
 	// function to cleanup and update status after control run completion
 	defer func() {
-		// update the result group status with our status - this will be passed all the way up the execution tree
-		r.Group.updateSummary(r.Summary)
-		if len(r.Severity) != 0 {
-			r.Group.updateSeverityCounts(r.Severity, r.Summary)
-		}
 		r.Duration = time.Since(startTime)
 		if r.Group != nil {
 			r.Group.addDuration(r.Duration)
 		}
+		r.complete(ctx)
 		log.Printf("[TRACE] finishing with concurrency, %s, , %d\n", r.Control.Name(), r.Tree.Progress.Executing)
 	}()
 
-	// get a db connection
-	sessionResult := r.acquireSession(ctx, client)
-	if sessionResult.Error != nil {
-		if !error_helpers.IsCancelledError(sessionResult.Error) {
-			log.Printf("[TRACE] controlRun %s execute failed to acquire session: %s", r.ControlId, sessionResult.Error)
-			sessionResult.Error = fmt.Errorf("error acquiring database connection, %s", sessionResult.Error.Error())
-			r.setError(ctx, sessionResult.Error)
-		}
+	// resolve the control query
+	resolvedQuery, err := r.resolveControlQuery(control)
+	if err != nil {
+		r.setError(ctx, err)
 		return
 	}
 
-	dbSession := sessionResult.Session
+	// if the control cache is enabled, try to serve the results from the cache
+	if cache := r.Tree.ControlCache; cache != nil {
+		if r.cacheKey, err = cache.key(resolvedQuery, r.Tree.SearchPath); err != nil {
+			log.Printf("[WARN] failed to build control cache key for %s: %s", control.Name(), err.Error())
+		} else if rows, ok := cache.get(r.cacheKey); ok {
+			log.Printf("[TRACE] serving results for %s from the control cache", control.Name())
+			r.setCachedResults(ctx, rows)
+			return
+		}
+	}
+
 	defer func() {
-		// do this in a closure, otherwise the argument will not get evaluated during calltime
-		dbSession.Close(error_helpers.IsContextCanceled(ctx))
+		// convert the data to snapshot format
+		r.Data = r.Rows.ToLeafData(r.getDimensionSchema())
 	}()
 
-	// set our status
-	r.RunStatus = dashboardtypes.RunRunning
+	for {
+		err := r.executeQuery(ctx, client, resolvedQuery)
+		// if the run has already been finished (i.e. it was cancelled), there is nothing more to do
+		if r.Finished() {
+			return
+		}
+		if err == nil {
+			r.setRunStatus(ctx, dashboardtypes.RunComplete)
+			r.createdOrderedResultRows()
+			r.cacheResults()
+			return
+		}
 
-	// update the current running control in the Progress renderer
-	r.Tree.Progress.OnControlStart(ctx, r)
-	defer func() {
-		// update Progress
-		if r.GetRunStatus() == dashboardtypes.RunError {
-			r.Tree.Progress.OnControlError(ctx, r)
-		} else {
-			r.Tree.Progress.OnControlComplete(ctx, r)
+		r.attempts++
+		if !r.shouldRetry(ctx, err) {
+			r.setError(ctx, err)
+			return
 		}
-	}()
+		log.Printf("[TRACE] control %s query failed with error %s - retrying (attempt %d)...", control.Name(), err, r.attempts+1)
+		r.resetResults()
+	}
+}
 
-	// resolve the control query
-	resolvedQuery, err := r.resolveControlQuery(control)
-	if err != nil {
-		r.setError(ctx, err)
-		return
+// complete updates the result group summaries with the summary of the run (this is passed all the way up the execution tree)
+// and reports the completion of the run to the Progress renderer
+func (r *ControlRun) complete(ctx context.Context) {
+	r.Group.updateSummary(r.Summary)
+	if len(r.Severity) != 0 {
+		r.Group.updateSeverityCounts(r.Severity, r.Summary)
+	}
+
+	if r.GetRunStatus() == dashboardtypes.RunError {
+		r.Tree.Progress.OnControlError(ctx, r)
+	} else {
+		r.Tree.Progress.OnControlComplete(ctx, r)
+	}
+}
+
+// executeQuery makes a single attempt at executing the control query and reading the results,
+// using a new database session and applying the control timeout (if set)
+func (r *ControlRun) executeQuery(ctx context.Context, client db_common.Client, resolvedQuery *modconfig.ResolvedQuery) error {
+	// get a db connection
+	sessionResult := r.acquireSession(ctx, client)
+	if sessionResult.Error != nil {
+		if error_helpers.IsCancelledError(sessionResult.Error) {
+			return sessionResult.Error
+		}
+		log.Printf("[TRACE] controlRun %s execute failed to acquire session: %s", r.ControlId, sessionResult.Error)
+		return fmt.Errorf("error acquiring database connection, %s", sessionResult.Error.Error())
+	}
+
+	queryCtx := ctx
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		queryCtx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
 	}
 
-	controlExecutionCtx := r.getControlQueryContext(ctx)
+	dbSession := sessionResult.Session
+	defer func() {
+		// do this in a closure, otherwise the argument will not get evaluated during calltime
+		// if the query was cancelled or timed out, wait for the connection to be cleaned up
+		dbSession.Close(queryCtx.Err() != nil)
+	}()
+
+	controlExecutionCtx := r.getControlQueryContext(queryCtx)
 
 	// execute the control query
 	// NOTE no need to pass an OnComplete callback - we are already closing our session after waiting for results
-	log.Printf("[TRACE] execute start for, %s\n", control.Name())
+	log.Printf("[TRACE] execute start for, %s\n", r.Control.Name())
 	queryResult, err := client.ExecuteInSession(controlExecutionCtx, dbSession, nil, resolvedQuery.ExecuteSQL, resolvedQuery.Args...)
-	log.Printf("[TRACE] execute finish for, %s\n", control.Name())
-
+	log.Printf("[TRACE] execute finish for, %s\n", r.Control.Name())
 	if err != nil {
-		r.attempts++
-
-		// is this an rpc EOF error - meaning that the plugin somehow crashed
-		if grpc.IsGRPCConnectivityError(err) {
-			if r.attempts < constants.MaxControlRunAttempts {
-				log.Printf("[TRACE] control %s query failed with plugin connectivity error %s - retrying...", r.Control.Name(), err)
-				// recurse into this function to retry using the original context - which Execute will use to create it's own timeout context
-				r.execute(ctx, client)
-				return
-			} else {
-				log.Printf("[TRACE] control %s query failed again with plugin connectivity error %s - NOT retrying...", r.Control.Name(), err)
-			}
-		}
-		r.setError(ctx, err)
-		return
+		return err
 	}
 
 	r.queryResult = queryResult
 
 	// now wait for control completion
-	log.Printf("[TRACE] wait result for, %s\n", control.Name())
-	r.waitForResults(ctx)
-	log.Printf("[TRACE] finish result for, %s\n", control.Name())
+	log.Printf("[TRACE] wait result for, %s\n", r.Control.Name())
+	defer log.Printf("[TRACE] finish result for, %s\n", r.Control.Name())
+	return r.waitForResults(queryCtx)
+}
+
+// shouldRetry returns whether the control query should be retried after failing with the given error
+func (r *ControlRun) shouldRetry(ctx context.Context, err error) bool {
+	// never retry if execution was cancelled
+	if error_helpers.IsContextCanceled(ctx) || error_helpers.IsCancelledError(err) {
+		return false
+	}
+	// retry up to the number of retries set for the control
+	if r.attempts <= r.retries {
+		return true
+	}
+	// is this an rpc EOF error - meaning that the plugin somehow crashed
+	if grpc.IsGRPCConnectivityError(err) {
+		if r.attempts < constants.MaxControlRunAttempts {
+			log.Printf("[TRACE] control %s query failed with plugin connectivity error %s - retrying...", r.Control.Name(), err)
+			return true
+		}
+		log.Printf("[TRACE] control %s query failed again with plugin connectivity error %s - NOT retrying...", r.Control.Name(), err)
+	}
+	return false
+}
+
+// resetResults clears any results read by a failed attempt, before the query is retried
+func (r *ControlRun) resetResults() {
+	r.stateLock.Lock()
+	r.Summary = &controlstatus.StatusSummary{}
+	r.stateLock.Unlock()
+	r.rowMap = make(map[string]ResultRows)
+	r.cacheRows = nil
 }
 
 // try to acquire a database session - retry up to 4 times if there is an error
@@ -308,46 +475,61 @@ func (r *ControlRun) resolveControlQuery(control *modconfig.Control) (*modconfig
 	return resolvedQuery, nil
 }
 
-func (r *ControlRun) waitForResults(ctx context.Context) {
-	defer func() {
-		dimensionsSchema := r.getDimensionSchema()
-		// convert the data to snapshot format
-		r.Data = r.Rows.ToLeafData(dimensionsSchema)
-	}()
-
+// waitForResults reads the control query results, returning any error which terminates the run
+func (r *ControlRun) waitForResults(ctx context.Context) error {
 	for {
 		select {
 		case <-ctx.Done():
-			r.setError(ctx, ctx.Err())
-			return
+			return ctx.Err()
 		case row := <-*r.queryResult.RowChan:
 			// nil row means control run is complete
 			if row == nil {
-				// nil row means we are done
-				r.setRunStatus(ctx, dashboardtypes.RunComplete)
-				r.createdOrderedResultRows()
-				return
+				return nil
 			}
 			// if the row is in error then we terminate the run
 			if row.Error != nil {
-				// set error status (parent summary will be set from parent defer)
-				r.setError(ctx, row.Error)
-				return
+				return row.Error
 			}
 
 			// so all is ok - create another result row
 			result, err := NewResultRow(r, row, r.queryResult.Cols)
 			if err != nil {
-				r.setError(ctx, err)
-				return
+				return err
+			}
+			// if the control cache is enabled, store the row as returned by the query
+			if r.cacheKey != "" {
+				r.cacheRows = append(r.cacheRows, newCachedResultRow(result))
 			}
 			r.addResultRow(result)
 		case <-r.doneChan:
-			return
+			return nil
 		}
 	}
 }
 
+// setCachedResults populates the results from the control cache
+func (r *ControlRun) setCachedResults(ctx context.Context, rows []*cachedResultRow) {
+	for _, row := range rows {
+		r.addResultRow(row.toResultRow(r))
+	}
+	r.Cached = true
+	r.setRunStatus(ctx, dashboardtypes.RunComplete)
+	r.createdOrderedResultRows()
+	// convert the data to snapshot format
+	r.Data = r.Rows.ToLeafData(r.getDimensionSchema())
+}
+
+// cacheResults writes the results of a successful control run to the control cache
+func (r *ControlRun) cacheResults() {
+	if r.cacheKey == "" {
+		return
+	}
+	if err := r.Tree.ControlCache.set(r.cacheKey, r.cacheRows); err != nil {
+		log.Printf("[WARN] failed to write results for %s to the control cache: %s", r.Control.Name(), err.Error())
+	}
+	r.cacheRows = nil
+}
+
 func (r *ControlRun) getDimensionSchema() map[string]*queryresult.ColumnDef {
 	var dimensionsSchema = make(map[string]*queryresult.ColumnDef)
 
@@ -371,27 +553,44 @@ func (r *ControlRun) getDimensionSchema() map[string]*queryresult.ColumnDef {
 
 // add the result row to our results and update the summary with the row status
 func (r *ControlRun) addResultRow(row *ResultRow) {
+	// if the remediation references the row columns, render it for this row
+	if r.remediationTemplate != nil {
+		row.Remediation = row.renderRemediation(r.remediationTemplate)
+	}
+
+	// if the result is a known alarm or error, which is suppressed by the baseline, reclassify it
+	if r.Tree.Baseline != nil && r.Tree.Baseline.Suppresses(row) {
+		row.Status = constants.ControlSuppressed
+	}
+
 	// update results
 	r.rowMap[row.Status] = append(r.rowMap[row.Status], row)
 
 	// update summary
-	switch row.Status {
+	updateStatusSummary(r.Summary, row.Status)
+}
+
+// updateStatusSummary increments the summary count for the given result status
+func updateStatusSummary(summary *controlstatus.StatusSummary, status string) {
+	switch status {
 	case constants.ControlOk:
-		r.Summary.Ok++
+		summary.Ok++
 	case constants.ControlAlarm:
-		r.Summary.Alarm++
+		summary.Alarm++
 	case constants.ControlSkip:
-		r.Summary.Skip++
+		summary.Skip++
 	case constants.ControlInfo:
-		r.Summary.Info++
+		summary.Info++
 	case constants.ControlError:
-		r.Summary.Error++
+		summary.Error++
+	case constants.ControlSuppressed:
+		summary.Suppressed++
 	}
 }
 
 // populate ordered list of rows
 func (r *ControlRun) createdOrderedResultRows() {
-	statusOrder := []string{constants.ControlError, constants.ControlAlarm, constants.ControlInfo, constants.ControlOk, constants.ControlSkip}
+	statusOrder := []string{constants.ControlError, constants.ControlAlarm, constants.ControlInfo, constants.ControlOk, constants.ControlSuppressed, constants.ControlSkip}
 	for _, status := range statusOrder {
 		r.Rows = append(r.Rows, r.rowMap[status]...)
 	}
@@ -0,0 +1,95 @@
+package controlexecute
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+func TestControlRunTimeoutAndRetries(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	parent := &ResultGroup{GroupItem: &modconfig.Benchmark{Timeout: intPtr(60), Retries: intPtr(2)}}
+	child := &ResultGroup{GroupItem: &modconfig.Benchmark{Timeout: intPtr(30)}, Parent: parent}
+	unset := &ResultGroup{GroupItem: &modconfig.Benchmark{}}
+
+	cases := map[string]struct {
+		control         *modconfig.Control
+		group           *ResultGroup
+		expectedTimeout time.Duration
+		expectedRetries int
+	}{
+		"set on control": {
+			control:         &modconfig.Control{Timeout: intPtr(10), Retries: intPtr(1)},
+			group:           child,
+			expectedTimeout: 10 * time.Second,
+			expectedRetries: 1,
+		},
+		"inherited from nearest benchmark": {
+			control:         &modconfig.Control{},
+			group:           child,
+			expectedTimeout: 30 * time.Second,
+			expectedRetries: 2,
+		},
+		"control overrides retries only": {
+			control:         &modconfig.Control{Retries: intPtr(0)},
+			group:           child,
+			expectedTimeout: 30 * time.Second,
+			expectedRetries: 0,
+		},
+		"not set": {
+			control:         &modconfig.Control{},
+			group:           unset,
+			expectedTimeout: 0,
+			expectedRetries: 0,
+		},
+	}
+	for name, c := range cases {
+		run := &ControlRun{Control: c.control, Group: c.group}
+		run.setTimeoutAndRetries()
+		if run.timeout != c.expectedTimeout || run.retries != c.expectedRetries {
+			t.Errorf("%s: expected timeout %s and %d retries, got timeout %s and %d retries", name, c.expectedTimeout, c.expectedRetries, run.timeout, run.retries)
+		}
+	}
+}
+
+func TestControlRunRemediation(t *testing.T) {
+	cases := map[string]struct {
+		remediation string
+		expected    []string
+	}{
+		"static remediation": {
+			remediation: "Enable bucket logging",
+			expected:    []string{"Enable bucket logging"},
+		},
+		"remediation referencing row columns": {
+			remediation: "Enable logging for {{ .resource }} in {{ .region }}{{ .missing }}",
+			expected:    []string{"Enable logging for bucket_a in us-east-1", "Enable logging for bucket_b in eu-west-1"},
+		},
+		"no remediation": {
+			remediation: "",
+			expected:    nil,
+		},
+	}
+	for name, c := range cases {
+		run := &ControlRun{FullName: "control.s3_logging", Remediation: c.remediation}
+		run.setRemediationTemplate()
+		run.Rows = []*ResultRow{
+			{Run: run, Resource: "bucket_a", Status: constants.ControlAlarm, Dimensions: []Dimension{{Key: "region", Value: "us-east-1"}}},
+			{Run: run, Resource: "bucket_b", Status: constants.ControlError, Dimensions: []Dimension{{Key: "region", Value: "eu-west-1"}}},
+			{Run: run, Resource: "bucket_c", Status: constants.ControlOk, Dimensions: []Dimension{{Key: "region", Value: "us-east-2"}}},
+		}
+		if run.remediationTemplate != nil {
+			for _, row := range run.Rows {
+				row.Remediation = row.renderRemediation(run.remediationTemplate)
+			}
+		}
+		actual := run.FailedRemediations()
+		if strings.Join(actual, "|") != strings.Join(c.expected, "|") {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package controlexecute
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+// DeltaResult is an alarm which is new or resolved compared with a previous check run
+type DeltaResult struct {
+	Reason     string      `json:"reason"`
+	Resource   string      `json:"resource"`
+	Dimensions []Dimension `json:"dimensions"`
+}
+
+// ControlDelta is the change in the alarms of a control compared with a previous check run
+type ControlDelta struct {
+	New       []*DeltaResult `json:"new"`
+	Resolved  []*DeltaResult `json:"resolved"`
+	Unchanged int            `json:"unchanged"`
+}
+
+// HasChanges returns whether there are any new or resolved alarms
+func (d *ControlDelta) HasChanges() bool {
+	return len(d.New)+len(d.Resolved) > 0
+}
+
+// DeltaSummary is the total change in alarms of an execution tree compared with a previous check run
+type DeltaSummary struct {
+	// the json output of the previous check run
+	ComparedTo string `json:"compared_to"`
+	New        int    `json:"new"`
+	Resolved   int    `json:"resolved"`
+	Unchanged  int    `json:"unchanged"`
+}
+
+// PreviousRun contains the alarms of a previous check run, loaded from its json output
+type PreviousRun struct {
+	Path string
+	// map of control id to map of result key to alarm
+	alarms map[string]map[string]*DeltaResult
+}
+
+// LoadPreviousRun loads the alarms from the json output of a previous check run
+func LoadPreviousRun(path string) (*PreviousRun, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load previous check run: %s", err.Error())
+	}
+	var root checkOutputGroup
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to load previous check run '%s' - expected the json output of a check run: %s", path, err.Error())
+	}
+
+	p := &PreviousRun{
+		Path:   path,
+		alarms: make(map[string]map[string]*DeltaResult),
+	}
+	for _, control := range root.allControls() {
+		for _, result := range control.Results {
+			if result.Status != constants.ControlAlarm {
+				continue
+			}
+			if p.alarms[control.ControlId] == nil {
+				p.alarms[control.ControlId] = make(map[string]*DeltaResult)
+			}
+			p.alarms[control.ControlId][deltaResultKey(result.Resource, result.Dimensions)] = &DeltaResult{
+				Reason:     result.Reason,
+				Resource:   result.Resource,
+				Dimensions: result.Dimensions,
+			}
+		}
+	}
+	return p, nil
+}
+
+// controlDelta compares the alarms of the control run with the alarms of the same control in the previous run
+func (p *PreviousRun) controlDelta(run *ControlRun) *ControlDelta {
+	// the previous run may have used either the full or unqualified control name
+	previousAlarms, ok := p.alarms[run.ControlId]
+	if !ok {
+		previousAlarms = p.alarms[run.FullName]
+	}
+
+	delta := &ControlDelta{}
+	currentAlarms := make(map[string]bool)
+	for _, row := range run.Rows {
+		if row.Status != constants.ControlAlarm {
+			continue
+		}
+		key := deltaResultKey(row.Resource, row.Dimensions)
+		currentAlarms[key] = true
+		if _, ok := previousAlarms[key]; ok {
+			delta.Unchanged++
+		} else {
+			delta.New = append(delta.New, &DeltaResult{
+				Reason:     row.Reason,
+				Resource:   row.Resource,
+				Dimensions: row.Dimensions,
+			})
+		}
+	}
+	for key, result := range previousAlarms {
+		if !currentAlarms[key] {
+			delta.Resolved = append(delta.Resolved, result)
+		}
+	}
+	sort.Slice(delta.Resolved, func(i, j int) bool {
+		return delta.Resolved[i].Resource < delta.Resolved[j].Resource
+	})
+	return delta
+}
+
+// computeDelta sets the delta of each control run, and the total delta of the tree
+func (e *ExecutionTree) computeDelta() {
+	e.Delta = &DeltaSummary{ComparedTo: e.PreviousRun.Path}
+	for _, run := range e.ControlRuns {
+		// there is nothing to compare if the control did not complete
+		if run.GetError() != nil {
+			continue
+		}
+		run.Delta = e.PreviousRun.controlDelta(run)
+		e.Delta.New += len(run.Delta.New)
+		e.Delta.Resolved += len(run.Delta.Resolved)
+		e.Delta.Unchanged += run.Delta.Unchanged
+	}
+}
+
+// deltaResultKey returns the key used to identify the same result in different check runs
+func deltaResultKey(resource string, dimensions []Dimension) string {
+	dimensionStrings := make([]string, len(dimensions))
+	for i, d := range dimensions {
+		dimensionStrings[i] = fmt.Sprintf("%s=%s", d.Key, d.Value)
+	}
+	sort.Strings(dimensionStrings)
+	return fmt.Sprintf("%s|%s", resource, strings.Join(dimensionStrings, ","))
+}
@@ -0,0 +1,56 @@
+package controlexecute
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+func TestControlDelta(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "previous.json")
+	previousOutput := `{
+  "groups": [
+    {
+      "groups": [],
+      "controls": [
+        {
+          "control_id": "control.s3_public",
+          "results": [
+            {"reason": "bucket_a is public", "resource": "bucket_a", "status": "alarm", "dimensions": [{"key": "region", "value": "us-east-1"}]},
+            {"reason": "bucket_b is public", "resource": "bucket_b", "status": "alarm", "dimensions": []},
+            {"reason": "bucket_c is private", "resource": "bucket_c", "status": "ok", "dimensions": []}
+          ]
+        }
+      ]
+    }
+  ],
+  "controls": null
+}`
+	if err := os.WriteFile(path, []byte(previousOutput), 0644); err != nil {
+		t.Fatal(err)
+	}
+	previousRun, err := LoadPreviousRun(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := &ControlRun{FullName: "aws_compliance.control.s3_public", ControlId: "control.s3_public"}
+	run.Rows = []*ResultRow{
+		{Run: run, Resource: "bucket_a", Status: constants.ControlAlarm, Dimensions: []Dimension{{Key: "region", Value: "us-east-1"}}},
+		{Run: run, Resource: "bucket_b", Status: constants.ControlOk},
+		{Run: run, Resource: "bucket_c", Status: constants.ControlAlarm},
+	}
+	delta := previousRun.controlDelta(run)
+
+	if delta.Unchanged != 1 {
+		t.Errorf("expected 1 unchanged alarm, got %d", delta.Unchanged)
+	}
+	if len(delta.New) != 1 || delta.New[0].Resource != "bucket_c" {
+		t.Errorf("expected new alarm for bucket_c, got %v", delta.New)
+	}
+	if len(delta.Resolved) != 1 || delta.Resolved[0].Resource != "bucket_b" {
+		t.Errorf("expected resolved alarm for bucket_b, got %v", delta.Resolved)
+	}
+}
@@ -34,9 +34,32 @@ type ExecutionTree struct {
 	// the current session search path
 	SearchPath []string             `json:"-"`
 	Workspace  *workspace.Workspace `json:"-"`
-	client     db_common.Client
+	// optional baseline of known results - matching alarms and errors are reported as suppressed
+	Baseline *Baseline `json:"-"`
+	// optional previous check run to compare the results with
+	PreviousRun *PreviousRun `json:"-"`
+	// the change in alarms compared with the previous run
+	Delta *DeltaSummary `json:"delta,omitempty"`
+	// optional cache of control results
+	ControlCache *ControlCache `json:"-"`
+	// optional policy determining which alarms cause the run to fail
+	FailureThreshold *FailureThreshold `json:"-"`
+	// the evaluation of the failure threshold
+	ThresholdResult *ThresholdResult `json:"threshold,omitempty"`
+	// optional filter applied to the result rows before they are output
+	ResultFilter *ResultFilter `json:"filter,omitempty"`
+	// optional grouping of the results, replacing the benchmark hierarchy
+	GroupBy *GroupBy `json:"group_by,omitempty"`
+	// if set, a weighted compliance score is computed for each result group
+	Scoring bool `json:"-"`
+	client  db_common.Client
+	// if the results are grouped, the original root which mirrors the benchmark hierarchy
+	benchmarkRoot *ResultGroup
 	// an optional map of control names used to filter the controls which are run
 	controlNameFilterMap map[string]bool
+	// the control runs which depend on other control runs, by dependency level
+	// (the runs of each level are executed once all runs of the previous level are complete)
+	dependentRuns [][]*ControlRun
 }
 
 func NewExecutionTree(ctx context.Context, workspace *workspace.Workspace, client db_common.Client, arg, controlFilterWhereClause string) (*ExecutionTree, error) {
@@ -63,12 +86,26 @@ func NewExecutionTree(ctx context.Context, workspace *workspace.Workspace, clien
 	// build tree of result groups, starting with a synthetic 'root' node
 	executionTree.Root = NewRootResultGroup(ctx, executionTree, rootItem)
 
+	// resolve the dependencies between control runs, to determine the order of execution
+	if err := executionTree.resolveDependencies(); err != nil {
+		return nil, err
+	}
+
 	// after tree has built, ControlCount will be set - create progress rendered
 	executionTree.Progress = controlstatus.NewControlProgress(len(executionTree.ControlRuns))
 
 	return executionTree, nil
 }
 
+// BenchmarkRoot returns the root of the result tree which mirrors the benchmark hierarchy
+// this is the same as Root, unless the results have been grouped
+func (e *ExecutionTree) BenchmarkRoot() *ResultGroup {
+	if e.benchmarkRoot != nil {
+		return e.benchmarkRoot
+	}
+	return e.Root
+}
+
 // IsExportSourceData implements ExportSourceData
 func (*ExecutionTree) IsExportSourceData() {}
 
@@ -108,8 +145,12 @@ func (e *ExecutionTree) Execute(ctx context.Context) controlstatus.StatusSummary
 	parallelismLock := semaphore.NewWeighted(maxParallelGoRoutines)
 
 	// just execute the root - it will traverse the tree
+	// (this does not execute runs which depend on other runs)
 	e.Root.execute(ctx, e.client, parallelismLock)
 
+	// now execute the dependent runs, one dependency level at a time
+	e.executeDependentRuns(ctx, parallelismLock, maxParallelGoRoutines)
+
 	if err := e.waitForActiveRunsToComplete(ctx, parallelismLock, maxParallelGoRoutines); err != nil {
 		log.Printf("[WARN] timed out waiting for active runs to complete")
 	}
@@ -118,7 +159,31 @@ func (e *ExecutionTree) Execute(ctx context.Context) controlstatus.StatusSummary
 	e.DimensionColorGenerator, _ = NewDimensionColorGenerator(4, 27)
 	e.DimensionColorGenerator.populate(e)
 
-	return e.Root.Summary.Status
+	// if a previous run was given, compute the change in alarms
+	if e.PreviousRun != nil {
+		e.computeDelta()
+	}
+	// if a failure threshold was given, evaluate it
+	if e.FailureThreshold != nil {
+		e.ThresholdResult = e.FailureThreshold.Evaluate(e)
+	}
+
+	// the returned status (which determines the exit code) includes all results, whether or not they are filtered
+	status := e.Root.Summary.Status
+	// if a result filter was given, apply it
+	if e.ResultFilter != nil {
+		e.applyResultFilter()
+	}
+	// if a grouping was given, rebuild the result tree
+	if e.GroupBy != nil {
+		e.applyGrouping()
+	}
+	// if scoring is enabled, compute the score of each result group (from the results which are output)
+	if e.Scoring {
+		e.computeScores()
+	}
+
+	return status
 }
 
 func (e *ExecutionTree) waitForActiveRunsToComplete(ctx context.Context, parallelismLock *semaphore.Weighted, maxParallelGoRoutines int64) error {
@@ -0,0 +1,108 @@
+package controlexecute
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/utils"
+)
+
+// the control severities, in increasing order
+var severityLevels = []string{"none", "low", "medium", "high", "critical"}
+
+// a fail-on policy, e.g. 'severity>=high'
+var failOnRegex = regexp.MustCompile(`^\s*severity\s*(>=|>)\s*([a-zA-Z]+)\s*$`)
+
+// FailureThreshold is a policy determining which alarms cause a check run to fail
+type FailureThreshold struct {
+	// the minimum severity of an alarm which counts towards the threshold - if empty, all alarms count
+	MinSeverity string `json:"min_severity,omitempty"`
+	// the number of counted alarms allowed before the run fails
+	MaxAlarms int `json:"max_alarms"`
+}
+
+// NewFailureThreshold parses a fail-on policy of the form 'severity>=<level>' or 'severity><level>'
+// if no policy is given, all alarms count towards the threshold
+func NewFailureThreshold(failOn string, maxAlarms int) (*FailureThreshold, error) {
+	if maxAlarms < 0 {
+		return nil, fmt.Errorf("the maximum number of alarms must be 0 or greater")
+	}
+	t := &FailureThreshold{MaxAlarms: maxAlarms}
+	if failOn == "" {
+		return t, nil
+	}
+
+	match := failOnRegex.FindStringSubmatch(failOn)
+	if match == nil {
+		return nil, fmt.Errorf("invalid failure policy '%s' - expected 'severity>=<level>', where level is one of %s", failOn, strings.Join(severityLevels, ", "))
+	}
+	operator, severity := match[1], strings.ToLower(match[2])
+	level := severityLevel(severity)
+	if level == -1 {
+		return nil, fmt.Errorf("invalid severity '%s' - must be one of %s", severity, strings.Join(severityLevels, ", "))
+	}
+	if operator == ">" {
+		if level == len(severityLevels)-1 {
+			return nil, fmt.Errorf("invalid failure policy '%s' - there is no severity greater than %s", failOn, severity)
+		}
+		level++
+	}
+	t.MinSeverity = severityLevels[level]
+	return t, nil
+}
+
+// IsExceeded returns whether the given number of counted alarms exceeds the threshold
+func (t *FailureThreshold) IsExceeded(alarms int) bool {
+	return alarms > t.MaxAlarms
+}
+
+// Evaluate counts the alarms of the execution tree which count towards the threshold
+func (t *FailureThreshold) Evaluate(tree *ExecutionTree) *ThresholdResult {
+	res := &ThresholdResult{Threshold: t}
+	for _, run := range tree.ControlRuns {
+		if t.includesSeverity(run.Severity) {
+			res.Alarms += run.Summary.Alarm
+		}
+	}
+	res.Exceeded = t.IsExceeded(res.Alarms)
+	return res
+}
+
+func (t *FailureThreshold) String() string {
+	str := fmt.Sprintf("max %d %s", t.MaxAlarms, utils.Pluralize("alarm", t.MaxAlarms))
+	if t.MinSeverity != "" {
+		str = fmt.Sprintf("severity >= %s, %s", t.MinSeverity, str)
+	}
+	return str
+}
+
+// includesSeverity returns whether alarms of controls with the given severity count towards the threshold
+// controls with no (or an unknown) severity are treated as severity 'none'
+func (t *FailureThreshold) includesSeverity(severity string) bool {
+	if t.MinSeverity == "" {
+		return true
+	}
+	level := severityLevel(strings.ToLower(severity))
+	if level == -1 {
+		level = 0
+	}
+	return level >= severityLevel(t.MinSeverity)
+}
+
+// ThresholdResult is the evaluation of a failure threshold against the results of an execution tree
+type ThresholdResult struct {
+	Threshold *FailureThreshold `json:"threshold"`
+	// the number of alarms which count towards the threshold
+	Alarms   int  `json:"alarms"`
+	Exceeded bool `json:"exceeded"`
+}
+
+func severityLevel(severity string) int {
+	for i, s := range severityLevels {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
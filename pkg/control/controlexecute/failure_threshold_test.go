@@ -0,0 +1,75 @@
+package controlexecute
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+)
+
+func TestNewFailureThreshold(t *testing.T) {
+	cases := map[string]string{
+		"":                  "",
+		"severity>=high":    "high",
+		" severity >= Low":  "low",
+		"severity>medium":   "high",
+		"severity>=none":    "none",
+		"severity>critical": "ERROR",
+		"severity>=severe":  "ERROR",
+		"severity<=high":    "ERROR",
+		"high":              "ERROR",
+	}
+	for failOn, expected := range cases {
+		threshold, err := NewFailureThreshold(failOn, 0)
+		if expected == "ERROR" {
+			if err == nil {
+				t.Errorf("'%s' should have errored - but did not", failOn)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("'%s' failed: %s", failOn, err)
+			continue
+		}
+		if threshold.MinSeverity != expected {
+			t.Errorf("'%s': expected min severity '%s', got '%s'", failOn, expected, threshold.MinSeverity)
+		}
+	}
+
+	if _, err := NewFailureThreshold("", -1); err == nil {
+		t.Errorf("a negative max alarms should have errored - but did not")
+	}
+}
+
+func TestFailureThresholdEvaluate(t *testing.T) {
+	tree := &ExecutionTree{
+		ControlRuns: []*ControlRun{
+			{Severity: "critical", Summary: &controlstatus.StatusSummary{Alarm: 1, Ok: 4}},
+			{Severity: "high", Summary: &controlstatus.StatusSummary{Alarm: 2}},
+			{Severity: "low", Summary: &controlstatus.StatusSummary{Alarm: 10}},
+			{Summary: &controlstatus.StatusSummary{Alarm: 5}},
+		},
+	}
+
+	cases := map[string]struct {
+		failOn    string
+		maxAlarms int
+		alarms    int
+		exceeded  bool
+	}{
+		"all alarms":         {failOn: "", maxAlarms: 0, alarms: 18, exceeded: true},
+		"high and above":     {failOn: "severity>=high", maxAlarms: 0, alarms: 3, exceeded: true},
+		"high within limit":  {failOn: "severity>=high", maxAlarms: 3, alarms: 3, exceeded: false},
+		"critical only":      {failOn: "severity>high", maxAlarms: 1, alarms: 1, exceeded: false},
+		"no severity counts": {failOn: "severity>=none", maxAlarms: 17, alarms: 18, exceeded: true},
+	}
+	for name, c := range cases {
+		threshold, err := NewFailureThreshold(c.failOn, c.maxAlarms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := threshold.Evaluate(tree)
+		if res.Alarms != c.alarms || res.Exceeded != c.exceeded {
+			t.Errorf("%s: expected %d alarms (exceeded %v), got %d (exceeded %v)", name, c.alarms, c.exceeded, res.Alarms, res.Exceeded)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package controlexecute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+)
+
+// ResultFilter is a filter applied to the result rows of a check run before they are output
+// the control summaries are recomputed to only include the rows which match the filter
+// NOTE: the filter only applies to the outputs of the run (the display, exports and snapshots)
+// - the exit code, failure threshold, baseline and saved results are based on all results (see ControlRun.AllRows)
+type ResultFilter struct {
+	// if set, only rows with one of these statuses are included
+	Statuses []string `json:"status,omitempty"`
+	// if set, only rows with matching dimension values are included
+	// a row must match all dimension keys, and any of the values given for each key
+	Dimensions map[string][]string `json:"dimensions,omitempty"`
+}
+
+// NewResultFilter creates a ResultFilter from a list of statuses and a list of dimension filters of the form 'key=value'
+func NewResultFilter(statuses []string, dimensions []string) (*ResultFilter, error) {
+	f := &ResultFilter{}
+	for _, status := range statuses {
+		status = strings.ToLower(strings.TrimSpace(status))
+		if !IsValidControlStatus(status) && status != constants.ControlSuppressed {
+			return nil, fmt.Errorf("invalid status filter '%s' - must be one of %s", status, strings.Join(filterStatuses(), ", "))
+		}
+		if !helpers.StringSliceContains(f.Statuses, status) {
+			f.Statuses = append(f.Statuses, status)
+		}
+	}
+
+	for _, dimension := range dimensions {
+		key, value, found := strings.Cut(dimension, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid dimension filter '%s' - expected 'key=value'", dimension)
+		}
+		if f.Dimensions == nil {
+			f.Dimensions = make(map[string][]string)
+		}
+		f.Dimensions[key] = append(f.Dimensions[key], strings.TrimSpace(value))
+	}
+	return f, nil
+}
+
+// Matches returns whether the result row is included by the filter
+func (f *ResultFilter) Matches(row *ResultRow) bool {
+	if !f.includesStatus(row.Status) {
+		return false
+	}
+	for key, values := range f.Dimensions {
+		if !helpers.StringSliceContains(values, row.GetDimensionValue(key)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *ResultFilter) String() string {
+	var filters []string
+	if len(f.Statuses) > 0 {
+		filters = append(filters, fmt.Sprintf("status=%s", strings.Join(f.Statuses, ",")))
+	}
+	// sort the dimension keys so the description is stable
+	keys := make([]string, 0, len(f.Dimensions))
+	for key := range f.Dimensions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		filters = append(filters, fmt.Sprintf("%s=%s", key, strings.Join(f.Dimensions[key], ",")))
+	}
+	return strings.Join(filters, " ")
+}
+
+func (f *ResultFilter) includesStatus(status string) bool {
+	return len(f.Statuses) == 0 || helpers.StringSliceContains(f.Statuses, status)
+}
+
+// applyResultFilter removes the result rows which do not match the filter,
+// and recomputes the summaries of all control runs and result groups
+func (e *ExecutionTree) applyResultFilter() {
+	e.Root.resetSummary()
+	for _, run := range e.ControlRuns {
+		run.applyResultFilter(e.ResultFilter)
+		run.Group.updateSummary(run.Summary)
+		if len(run.Severity) != 0 {
+			run.Group.updateSeverityCounts(run.Severity, run.Summary)
+		}
+	}
+}
+
+// applyResultFilter removes the result rows which do not match the filter and recomputes the summary
+func (r *ControlRun) applyResultFilter(filter *ResultFilter) {
+	var rows ResultRows
+	summary := &controlstatus.StatusSummary{}
+	for _, row := range r.Rows {
+		if filter.Matches(row) {
+			rows = append(rows, row)
+			updateStatusSummary(summary, row.Status)
+		}
+	}
+	// a control run error is counted as an error result
+	if r.runError != nil && filter.includesStatus(constants.ControlError) {
+		summary.Error++
+	}
+
+	r.stateLock.Lock()
+	r.unfilteredRows = r.Rows
+	r.unfilteredSummary = r.Summary
+	r.Rows = rows
+	r.Summary = summary
+	r.stateLock.Unlock()
+
+	// rebuild the snapshot data from the filtered rows
+	r.DimensionKeys = nil
+	r.Data = r.Rows.ToLeafData(r.getDimensionSchema())
+}
+
+// AllRows returns all result rows of the run, including any rows removed by the result filter
+func (r *ControlRun) AllRows() ResultRows {
+	if r.unfilteredSummary != nil {
+		return r.unfilteredRows
+	}
+	return r.Rows
+}
+
+// AllRowsSummary returns the summary of all result rows of the run, including any rows removed by the result filter
+func (r *ControlRun) AllRowsSummary() *controlstatus.StatusSummary {
+	if r.unfilteredSummary != nil {
+		return r.unfilteredSummary
+	}
+	return r.Summary
+}
+
+// resetSummary clears the summary of the group and all descendant groups
+func (r *ResultGroup) resetSummary() {
+	r.Summary.Status = controlstatus.StatusSummary{}
+	r.Summary.Severity = make(map[string]controlstatus.StatusSummary)
+	for _, child := range r.Groups {
+		child.resetSummary()
+	}
+}
+
+// filterStatuses returns the statuses which may be used in a status filter
+func filterStatuses() []string {
+	return []string{constants.ControlOk, constants.ControlAlarm, constants.ControlInfo, constants.ControlError, constants.ControlSkip, constants.ControlSuppressed}
+}
@@ -0,0 +1,114 @@
+package controlexecute
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+)
+
+func TestNewResultFilter(t *testing.T) {
+	cases := map[string]struct {
+		statuses   []string
+		dimensions []string
+		expected   string
+		valid      bool
+	}{
+		"statuses":                   {statuses: []string{"alarm", " ERROR", "alarm"}, expected: "status=alarm,error", valid: true},
+		"dimensions":                 {dimensions: []string{"region=us-east-1", "region=us-east-2", "account_id=123"}, expected: "account_id=123 region=us-east-1,us-east-2", valid: true},
+		"statuses and dimensions":    {statuses: []string{"suppressed"}, dimensions: []string{"region=us-east-1"}, expected: "status=suppressed region=us-east-1", valid: true},
+		"invalid status":             {statuses: []string{"failed"}},
+		"dimension without value":    {dimensions: []string{"region"}},
+		"dimension with missing key": {dimensions: []string{"=us-east-1"}},
+	}
+	for name, c := range cases {
+		filter, err := NewResultFilter(c.statuses, c.dimensions)
+		if !c.valid {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err.Error())
+			continue
+		}
+		if actual := filter.String(); actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", name, c.expected, actual)
+		}
+	}
+}
+
+func TestResultFilterMatches(t *testing.T) {
+	filter, err := NewResultFilter([]string{"alarm", "error"}, []string{"region=us-east-1", "region=us-east-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	region := func(r string) []Dimension { return []Dimension{{Key: "region", Value: r}} }
+
+	cases := map[string]struct {
+		row      *ResultRow
+		expected bool
+	}{
+		"matching status and dimension": {
+			row:      &ResultRow{Status: constants.ControlAlarm, Dimensions: region("us-east-1")},
+			expected: true,
+		},
+		"matching status and second dimension value": {
+			row:      &ResultRow{Status: constants.ControlError, Dimensions: region("us-east-2")},
+			expected: true,
+		},
+		"other status": {
+			row:      &ResultRow{Status: constants.ControlOk, Dimensions: region("us-east-1")},
+			expected: false,
+		},
+		"other dimension value": {
+			row:      &ResultRow{Status: constants.ControlAlarm, Dimensions: region("eu-west-1")},
+			expected: false,
+		},
+		"missing dimension": {
+			row:      &ResultRow{Status: constants.ControlAlarm},
+			expected: false,
+		},
+	}
+	for name, c := range cases {
+		if actual := filter.Matches(c.row); actual != c.expected {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, actual)
+		}
+	}
+}
+
+func TestApplyResultFilter(t *testing.T) {
+	filter, err := NewResultFilter([]string{"alarm"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := &ResultGroup{Summary: NewGroupSummary(), updateLock: new(sync.Mutex)}
+	run := &ControlRun{FullName: "aws_compliance.control.s3_public", Group: root}
+	run.Rows = []*ResultRow{
+		{Run: run, Resource: "bucket_a", Status: constants.ControlAlarm},
+		{Run: run, Resource: "bucket_b", Status: constants.ControlError},
+		{Run: run, Resource: "bucket_c", Status: constants.ControlOk},
+	}
+	run.Summary = &controlstatus.StatusSummary{Alarm: 1, Error: 1, Ok: 1}
+	tree := &ExecutionTree{Root: root, ControlRuns: []*ControlRun{run}, ResultFilter: filter}
+
+	tree.applyResultFilter()
+
+	if len(run.Rows) != 1 || run.Rows[0].Resource != "bucket_a" {
+		t.Errorf("expected only the alarm row after filtering, got %d rows", len(run.Rows))
+	}
+	if expected := (controlstatus.StatusSummary{Alarm: 1}); *run.Summary != expected || root.Summary.Status != expected {
+		t.Errorf("expected filtered summary %v, got run summary %v and root summary %v", expected, *run.Summary, root.Summary.Status)
+	}
+	// all rows are still available, e.g. to write the baseline
+	if len(run.AllRows()) != 3 {
+		t.Errorf("expected AllRows to return all 3 rows, got %d", len(run.AllRows()))
+	}
+	baseline := NewBaseline()
+	baseline.AddExecutionTree(tree, nil)
+	if len(baseline.Suppressions) != 2 {
+		t.Errorf("expected the baseline to contain the alarm and error results, got %d suppressions", len(baseline.Suppressions))
+	}
+}
@@ -56,6 +56,8 @@ type ResultGroup struct {
 type GroupSummary struct {
 	Status   controlstatus.StatusSummary            `json:"status"`
 	Severity map[string]controlstatus.StatusSummary `json:"-"`
+	// the weighted compliance score (0-100) - only set if scoring is enabled and the group has scored results
+	Score *float64 `json:"score,omitempty"`
 }
 
 func NewGroupSummary() *GroupSummary {
@@ -268,6 +270,7 @@ func (r *ResultGroup) updateSummary(summary *controlstatus.StatusSummary) {
 	r.Summary.Status.Info += summary.Info
 	r.Summary.Status.Ok += summary.Ok
 	r.Summary.Status.Error += summary.Error
+	r.Summary.Status.Suppressed += summary.Suppressed
 
 	if r.Parent != nil {
 		r.Parent.updateSummary(summary)
@@ -287,6 +290,7 @@ func (r *ResultGroup) updateSeverityCounts(severity string, summary *controlstat
 	val.Info += summary.Info
 	val.Ok += summary.Ok
 	val.Skip += summary.Skip
+	val.Suppressed += summary.Suppressed
 
 	r.Summary.Severity[severity] = val
 	if r.Parent != nil {
@@ -299,29 +303,38 @@ func (r *ResultGroup) execute(ctx context.Context, client db_common.Client, para
 	defer log.Printf("[TRACE] end ResultGroup.Execute: %s\n", r.GroupId)
 
 	for _, controlRun := range r.ControlRuns {
-		if error_helpers.IsContextCanceled(ctx) {
-			controlRun.setError(ctx, ctx.Err())
+		// runs which depend on other runs are executed by the execution tree, once their dependencies are complete
+		if controlRun.dependencyLevel > 0 {
 			continue
 		}
-
-		if viper.GetBool(constants.ArgDryRun) {
-			controlRun.skip(ctx)
-			continue
-		}
-
-		err := parallelismLock.Acquire(ctx, 1)
-		if err != nil {
-			controlRun.setError(ctx, err)
-			continue
-		}
-
-		go executeRun(ctx, controlRun, parallelismLock, client)
+		startRun(ctx, controlRun, parallelismLock, client)
 	}
 	for _, child := range r.Groups {
 		child.execute(ctx, client, parallelismLock)
 	}
 }
 
+// startRun acquires the parallelism lock and executes the run asynchronously
+func startRun(ctx context.Context, controlRun *ControlRun, parallelismLock *semaphore.Weighted, client db_common.Client) {
+	if error_helpers.IsContextCanceled(ctx) {
+		controlRun.setError(ctx, ctx.Err())
+		return
+	}
+
+	if viper.GetBool(constants.ArgDryRun) {
+		controlRun.skip(ctx)
+		return
+	}
+
+	err := parallelismLock.Acquire(ctx, 1)
+	if err != nil {
+		controlRun.setError(ctx, err)
+		return
+	}
+
+	go executeRun(ctx, controlRun, parallelismLock, client)
+}
+
 func executeRun(ctx context.Context, run *ControlRun, parallelismLock *semaphore.Weighted, client db_common.Client) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -0,0 +1,213 @@
+package controlexecute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+const (
+	GroupByDimension = "dimension"
+	GroupByTag       = "tag"
+	GroupBySeverity  = "severity"
+)
+
+// the group value used for results which do not have a value for the grouping key
+const noGroupValue = "<none>"
+
+// GroupBy determines how the results of a check run are grouped for output,
+// replacing the benchmark hierarchy with a group for each distinct dimension value, tag value or severity
+type GroupBy struct {
+	// one of 'dimension', 'tag' or 'severity'
+	Type string `json:"type"`
+	// the dimension name or tag key (not set when grouping by severity)
+	Key string `json:"key,omitempty"`
+}
+
+// NewGroupBy parses a grouping of the form 'dimension:<name>', 'tag:<key>' or 'severity'
+func NewGroupBy(arg string) (*GroupBy, error) {
+	groupType, key, _ := strings.Cut(strings.TrimSpace(arg), ":")
+	groupType = strings.ToLower(strings.TrimSpace(groupType))
+	key = strings.TrimSpace(key)
+
+	switch groupType {
+	case GroupByDimension, GroupByTag:
+		if key == "" {
+			return nil, fmt.Errorf("invalid grouping '%s' - expected '%s:<name>'", arg, groupType)
+		}
+	case GroupBySeverity:
+		if key != "" {
+			return nil, fmt.Errorf("invalid grouping '%s' - expected 'severity'", arg)
+		}
+	default:
+		return nil, fmt.Errorf("invalid grouping '%s' - expected 'dimension:<name>', 'tag:<key>' or 'severity'", arg)
+	}
+	return &GroupBy{Type: groupType, Key: key}, nil
+}
+
+func (g *GroupBy) String() string {
+	if g.Key == "" {
+		return g.Type
+	}
+	return fmt.Sprintf("%s:%s", g.Type, g.Key)
+}
+
+// the label used for the group titles, i.e. the dimension name, tag key or 'severity'
+func (g *GroupBy) label() string {
+	if g.Key == "" {
+		return g.Type
+	}
+	return g.Key
+}
+
+// sortValues sorts the group values - severities are ordered from most to least severe,
+// other values alphabetically, with results which have no value last
+func (g *GroupBy) sortValues(values []string) {
+	sort.SliceStable(values, func(i, j int) bool {
+		if values[i] == noGroupValue || values[j] == noGroupValue {
+			return values[j] == noGroupValue && values[i] != noGroupValue
+		}
+		if g.Type == GroupBySeverity {
+			if li, lj := severityLevel(values[i]), severityLevel(values[j]); li != lj {
+				return li > lj
+			}
+		}
+		return values[i] < values[j]
+	})
+}
+
+// applyGrouping replaces the result tree root with a root whose child groups are built from the executed control runs
+// the original root is retained as the benchmark root, which is used for snapshots
+func (e *ExecutionTree) applyGrouping() {
+	root := &ResultGroup{
+		GroupId:    RootResultGroupName,
+		Title:      e.Root.Title,
+		Groups:     []*ResultGroup{},
+		Tags:       make(map[string]string),
+		Summary:    NewGroupSummary(),
+		Severity:   make(map[string]controlstatus.StatusSummary),
+		updateLock: new(sync.Mutex),
+		NodeType:   modconfig.BlockTypeBenchmark,
+	}
+
+	// build a map of group value to the control runs in that group
+	groupRuns := make(map[string][]*ControlRun)
+	for _, run := range e.ControlRuns {
+		for value, rows := range e.GroupBy.partition(run) {
+			groupRuns[value] = append(groupRuns[value], run.cloneWithRows(rows, value == noGroupValue))
+		}
+	}
+
+	values := make([]string, 0, len(groupRuns))
+	for value := range groupRuns {
+		values = append(values, value)
+	}
+	e.GroupBy.sortValues(values)
+
+	for _, value := range values {
+		group := &ResultGroup{
+			GroupId:    fmt.Sprintf("%s.%s", e.GroupBy.label(), value),
+			Title:      fmt.Sprintf("%s: %s", e.GroupBy.label(), value),
+			Groups:     []*ResultGroup{},
+			Tags:       make(map[string]string),
+			Parent:     root,
+			Summary:    NewGroupSummary(),
+			Severity:   make(map[string]controlstatus.StatusSummary),
+			updateLock: new(sync.Mutex),
+			NodeType:   modconfig.BlockTypeBenchmark,
+		}
+		for _, run := range groupRuns[value] {
+			run.Group = group
+			group.addControl(run)
+			group.updateSummary(run.Summary)
+			if len(run.Severity) != 0 {
+				group.updateSeverityCounts(run.Severity, run.Summary)
+			}
+			group.addDuration(run.Duration)
+			run.Data = run.Rows.ToLeafData(run.getDimensionSchema())
+		}
+		root.addResultGroup(group)
+	}
+
+	e.benchmarkRoot = e.Root
+	e.Root = root
+}
+
+// partition returns the result rows of the control run, keyed by group value
+// when grouping by dimension, the rows are split by their dimension value -
+// a run error, or a run with no rows, is grouped under noGroupValue
+func (g *GroupBy) partition(run *ControlRun) map[string]ResultRows {
+	switch g.Type {
+	case GroupByTag:
+		value, ok := run.Tags[g.Key]
+		if !ok || value == "" {
+			value = noGroupValue
+		}
+		return map[string]ResultRows{value: run.Rows}
+	case GroupBySeverity:
+		value := run.Severity
+		if value == "" {
+			value = "none"
+		}
+		return map[string]ResultRows{value: run.Rows}
+	}
+
+	res := make(map[string]ResultRows)
+	for _, row := range run.Rows {
+		value := row.GetDimensionValue(g.Key)
+		if value == "" {
+			value = noGroupValue
+		}
+		res[value] = append(res[value], row)
+	}
+	if len(run.Rows) == 0 || run.runError != nil {
+		if _, ok := res[noGroupValue]; !ok {
+			res[noGroupValue] = nil
+		}
+	}
+	return res
+}
+
+// cloneWithRows returns a copy of the control run containing only the given rows
+// the summary is recomputed from the rows - the run error (if any) is only included if includeError is set
+func (r *ControlRun) cloneWithRows(rows ResultRows, includeError bool) *ControlRun {
+	clone := &ControlRun{
+		ControlId:           r.ControlId,
+		FullName:            r.FullName,
+		Title:               r.Title,
+		Description:         r.Description,
+		Documentation:       r.Documentation,
+		Tags:                r.Tags,
+		Display:             r.Display,
+		Type:                r.Type,
+		Remediation:         r.Remediation,
+		References:          r.References,
+		Severity:            r.Severity,
+		NodeType:            r.NodeType,
+		Control:             r.Control,
+		Summary:             &controlstatus.StatusSummary{},
+		RunStatus:           r.GetRunStatus(),
+		Rows:                rows,
+		Delta:               r.Delta,
+		Cached:              r.Cached,
+		Duration:            r.Duration,
+		Tree:                r.Tree,
+		timeout:             r.timeout,
+		retries:             r.retries,
+		remediationTemplate: r.remediationTemplate,
+	}
+	for _, row := range rows {
+		updateStatusSummary(clone.Summary, row.Status)
+	}
+	if includeError && r.runError != nil {
+		clone.runError = r.runError
+		clone.RunErrorString = r.RunErrorString
+		clone.RunErrorReason = r.RunErrorReason
+		clone.Summary.Error++
+	}
+	return clone
+}
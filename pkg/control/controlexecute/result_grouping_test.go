@@ -0,0 +1,98 @@
+package controlexecute
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+)
+
+func TestNewGroupBy(t *testing.T) {
+	cases := map[string]struct {
+		arg      string
+		expected string
+		valid    bool
+	}{
+		"dimension":                {arg: "dimension:account_id", expected: "dimension:account_id", valid: true},
+		"tag":                      {arg: " tag : service ", expected: "tag:service", valid: true},
+		"severity":                 {arg: "Severity", expected: "severity", valid: true},
+		"dimension without name":   {arg: "dimension"},
+		"tag without key":          {arg: "tag:"},
+		"severity with key":        {arg: "severity:high"},
+		"unsupported grouping":     {arg: "benchmark"},
+		"unsupported grouping key": {arg: "control:title"},
+	}
+	for name, c := range cases {
+		groupBy, err := NewGroupBy(c.arg)
+		if !c.valid {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err.Error())
+			continue
+		}
+		if actual := groupBy.String(); actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", name, c.expected, actual)
+		}
+	}
+}
+
+func TestApplyGroupingByDimension(t *testing.T) {
+	region := func(r string) []Dimension { return []Dimension{{Key: "region", Value: r}} }
+	root := &ResultGroup{GroupId: RootResultGroupName, Title: "All controls", Summary: NewGroupSummary(), updateLock: new(sync.Mutex)}
+
+	logging := &ControlRun{FullName: "control.logging", Summary: &controlstatus.StatusSummary{}, Group: root, Severity: "high"}
+	logging.Rows = ResultRows{
+		{Status: constants.ControlAlarm, Dimensions: region("us-east-1")},
+		{Status: constants.ControlOk, Dimensions: region("us-east-1")},
+		{Status: constants.ControlAlarm, Dimensions: region("eu-west-1")},
+	}
+	versioning := &ControlRun{FullName: "control.versioning", Summary: &controlstatus.StatusSummary{}, Group: root}
+	versioning.Rows = ResultRows{
+		{Status: constants.ControlOk, Dimensions: region("us-east-1")},
+		{Status: constants.ControlOk},
+	}
+
+	tree := &ExecutionTree{
+		Root:        root,
+		ControlRuns: []*ControlRun{logging, versioning},
+		GroupBy:     &GroupBy{Type: GroupByDimension, Key: "region"},
+	}
+	tree.applyGrouping()
+
+	if tree.BenchmarkRoot() != root {
+		t.Fatalf("expected the benchmark root to be retained")
+	}
+	expected := []struct {
+		title    string
+		controls int
+		ok       int
+		alarm    int
+	}{
+		{title: "region: eu-west-1", controls: 1, alarm: 1},
+		{title: "region: us-east-1", controls: 2, ok: 2, alarm: 1},
+		{title: "region: <none>", controls: 1, ok: 1},
+	}
+	groups := tree.Root.Groups
+	if len(groups) != len(expected) {
+		t.Fatalf("expected %d groups, got %d", len(expected), len(groups))
+	}
+	for i, e := range expected {
+		g := groups[i]
+		if g.Title != e.title || len(g.ControlRuns) != e.controls || g.Summary.Status.Ok != e.ok || g.Summary.Status.Alarm != e.alarm {
+			t.Errorf("group %d: expected %s with %d controls, %d ok and %d alarm, got %s with %d controls, %d ok and %d alarm",
+				i, e.title, e.controls, e.ok, e.alarm, g.Title, len(g.ControlRuns), g.Summary.Status.Ok, g.Summary.Status.Alarm)
+		}
+	}
+	if status := tree.Root.Summary.Status; status.Ok != 3 || status.Alarm != 2 {
+		t.Errorf("expected the grouped root to have 3 ok and 2 alarm, got %d ok and %d alarm", status.Ok, status.Alarm)
+	}
+	// the original control runs must not be modified
+	if len(logging.Rows) != 3 || logging.Group != root {
+		t.Errorf("expected the original control run to be unchanged")
+	}
+}
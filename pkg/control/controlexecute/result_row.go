@@ -1,7 +1,10 @@
 package controlexecute
 
 import (
+	"bytes"
 	"fmt"
+	"log"
+	"text/template"
 
 	"github.com/turbot/go-kit/helpers"
 	typehelpers "github.com/turbot/go-kit/types"
@@ -51,6 +54,8 @@ type ResultRow struct {
 	Status string `json:"status" csv:"status"`
 	// dimensions for this row
 	Dimensions []Dimension `json:"dimensions"`
+	// the control remediation rendered for this row (only set if the remediation references the row columns)
+	Remediation string `json:"remediation,omitempty" csv:"remediation"`
 	// parent control run
 	Run *ControlRun `json:"-"`
 	// source control
@@ -67,6 +72,33 @@ func (r *ResultRow) GetDimensionValue(key string) string {
 	return ""
 }
 
+// GetRemediation returns the remediation for this row - either the remediation rendered for the row,
+// or the control remediation
+func (r *ResultRow) GetRemediation() string {
+	if r.Remediation != "" {
+		return r.Remediation
+	}
+	return r.Run.Remediation
+}
+
+// renderRemediation renders the remediation template using the row columns
+func (r *ResultRow) renderRemediation(remediationTemplate *template.Template) string {
+	columns := map[string]string{
+		"reason":   r.Reason,
+		"resource": r.Resource,
+		"status":   r.Status,
+	}
+	for _, d := range r.Dimensions {
+		columns[d.Key] = d.Value
+	}
+	var b bytes.Buffer
+	if err := remediationTemplate.Execute(&b, columns); err != nil {
+		log.Printf("[WARN] failed to render remediation for %s: %s", r.Run.FullName, err.Error())
+		return r.Run.Remediation
+	}
+	return b.String()
+}
+
 // AddDimension checks whether a column value is a scalar type, and if so adds it to the Dimensions map
 func (r *ResultRow) AddDimension(c *queryresult.ColumnDef, val interface{}) {
 	r.Dimensions = append(r.Dimensions, Dimension{
@@ -0,0 +1,75 @@
+package controlexecute
+
+import (
+	"math"
+
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+// computeScores computes the weighted score of each result group in the tree
+// (and of the benchmark hierarchy, if the results have been grouped)
+func (e *ExecutionTree) computeScores() {
+	e.Root.computeScore()
+	if e.benchmarkRoot != nil {
+		e.benchmarkRoot.computeScore()
+	}
+}
+
+// computeScore sets the score of the group and all descendant groups, and returns the (unrounded) score of the group
+//
+// the score of a control is the percentage of its ok results out of its ok, alarm and error results,
+// the score of a group is the average of the scores of its child groups and controls, weighted by their 'weight'
+// children with no scored results, or with a weight of 0, do not contribute to the score of their parent
+// if no children contribute, the group has no score
+func (r *ResultGroup) computeScore() *float64 {
+	var weightedTotal float64
+	var totalWeight int
+	for _, child := range r.Groups {
+		score := child.computeScore()
+		weight := child.weight()
+		if score == nil || weight == 0 {
+			continue
+		}
+		weightedTotal += *score * float64(weight)
+		totalWeight += weight
+	}
+	for _, run := range r.ControlRuns {
+		score := statusScore(run.Summary)
+		weight := run.Control.GetWeight()
+		if score == nil || weight == 0 {
+			continue
+		}
+		weightedTotal += *score * float64(weight)
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		r.Summary.Score = nil
+		return nil
+	}
+	score := weightedTotal / float64(totalWeight)
+	rounded := math.Round(score*100) / 100
+	r.Summary.Score = &rounded
+	return &score
+}
+
+// weight returns the weight of the group when calculating the score of its parent
+// groups which do not correspond to a benchmark (i.e. the root group and the groups of grouped results) have the default weight
+func (r *ResultGroup) weight() int {
+	if benchmark, ok := r.GroupItem.(*modconfig.Benchmark); ok {
+		return benchmark.GetWeight()
+	}
+	return modconfig.DefaultWeight
+}
+
+// statusScore returns the percentage of ok results out of the ok, alarm and error results of the summary,
+// or nil if there are none of these results
+func statusScore(summary *controlstatus.StatusSummary) *float64 {
+	scored := summary.Ok + summary.Alarm + summary.Error
+	if scored == 0 {
+		return nil
+	}
+	score := float64(summary.Ok) * 100 / float64(scored)
+	return &score
+}
@@ -0,0 +1,75 @@
+package controlexecute
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/pkg/utils"
+)
+
+func newScoreTestRun(ok, alarm, error, skip int, weight *int) *ControlRun {
+	return &ControlRun{
+		Control: &modconfig.Control{Weight: weight},
+		Summary: &controlstatus.StatusSummary{Ok: ok, Alarm: alarm, Error: error, Skip: skip},
+	}
+}
+
+func newScoreTestGroup(weight *int, groups []*ResultGroup, runs ...*ControlRun) *ResultGroup {
+	return &ResultGroup{
+		GroupItem:   &modconfig.Benchmark{Weight: weight},
+		Groups:      groups,
+		ControlRuns: runs,
+		Summary:     NewGroupSummary(),
+	}
+}
+
+func TestComputeScore(t *testing.T) {
+	// 3 of 4 ok (75%) with weight 3, and all ok (100%) with default weight 1 - (75*3 + 100) / 4 = 81.25
+	weighted := newScoreTestGroup(nil, nil,
+		newScoreTestRun(3, 1, 0, 0, utils.ToIntegerPointer(3)),
+		newScoreTestRun(2, 0, 0, 0, nil),
+	)
+	// 1 of 3 ok (33.33%) - the skipped results and the run with no scored results are not included
+	unweighted := newScoreTestGroup(utils.ToIntegerPointer(2), nil,
+		newScoreTestRun(1, 1, 1, 5, nil),
+		newScoreTestRun(0, 0, 0, 2, nil),
+	)
+	// a group with a weight of 0 does not contribute to the score of its parent
+	excluded := newScoreTestGroup(utils.ToIntegerPointer(0), nil,
+		newScoreTestRun(0, 1, 0, 0, nil),
+	)
+	// a group with no scored results has no score
+	skipped := newScoreTestGroup(nil, nil,
+		newScoreTestRun(0, 0, 0, 1, nil),
+	)
+	root := newScoreTestGroup(nil, []*ResultGroup{weighted, unweighted, excluded, skipped})
+
+	root.computeScore()
+
+	expected := map[string]struct {
+		group *ResultGroup
+		// the expected score - nil if the group has no score
+		score interface{}
+	}{
+		"weighted controls": {weighted, 81.25},
+		"unweighted":        {unweighted, 33.33},
+		"excluded":          {excluded, float64(0)},
+		"skipped":           {skipped, nil},
+		// (81.25 + 33.333 * 2) / 3 = 49.31
+		"root": {root, 49.31},
+	}
+	for name, test := range expected {
+		actual := test.group.Summary.Score
+		if formatTestScore(actual) != test.score {
+			t.Errorf("Test: '%s' FAILED: expected score %v, got %v", name, test.score, formatTestScore(actual))
+		}
+	}
+}
+
+func formatTestScore(score *float64) interface{} {
+	if score == nil {
+		return nil
+	}
+	return *score
+}
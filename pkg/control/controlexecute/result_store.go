@@ -0,0 +1,102 @@
+package controlexecute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+)
+
+// the columns of the check result table, in the order of the values returned by checkResultValues
+var checkResultColumns = []string{"run_id", "control", "resource", "status", "reason", "dimensions"}
+
+// SaveResults stores the results of the check run in the check results tables of the database,
+// returning the id of the stored run
+// all results of the run are stored, including any which were removed from the output by the result filter
+// target is the benchmark or control which was run, and schedule the name of the schedule which ran it (if any)
+func (e *ExecutionTree) SaveResults(ctx context.Context, target, schedule string) (runId string, err error) {
+	runId = uuid.New().String()
+	summary, results, err := e.checkResultValues(runId)
+	if err != nil {
+		return "", err
+	}
+
+	sessionResult := e.client.AcquireSession(ctx)
+	if sessionResult.Error != nil {
+		return "", sessionResult.Error
+	}
+	defer func() {
+		// we need to do this in a closure, otherwise the ctx will be evaluated immediately
+		// and not in call-time
+		sessionResult.Session.Close(error_helpers.IsContextCanceled(ctx))
+	}()
+
+	tx, err := sessionResult.Session.Connection.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+			err = fmt.Errorf("failed to save check results: %s", err.Error())
+		}
+	}()
+
+	var scheduleName *string
+	if schedule != "" {
+		scheduleName = &schedule
+	}
+	runInsert := fmt.Sprintf(`insert into %s.%s (run_id, schedule, target, start_time, end_time, ok, alarm, info, skip, error, suppressed)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`, constants.CheckResultsSchema, constants.CheckRunTable)
+	if _, err = tx.Exec(ctx, runInsert, runId, scheduleName, target, e.StartTime, e.EndTime, summary.Ok, summary.Alarm, summary.Info, summary.Skip, summary.Error, summary.Suppressed); err != nil {
+		return "", err
+	}
+
+	if _, err = tx.CopyFrom(ctx, pgx.Identifier{constants.CheckResultsSchema, constants.CheckResultTable}, checkResultColumns, pgx.CopyFromRows(results)); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return runId, nil
+}
+
+// checkResultValues returns the summary of all results of the run, and the values of a check result table row for each result
+func (e *ExecutionTree) checkResultValues(runId string) (*controlstatus.StatusSummary, [][]any, error) {
+	summary := &controlstatus.StatusSummary{}
+	var results [][]any
+	for _, run := range e.ControlRuns {
+		summary.Merge(run.AllRowsSummary())
+		// a control run error is stored as an error result with no resource
+		if run.runError != nil {
+			results = append(results, []any{runId, run.FullName, nil, constants.ControlError, run.RunErrorString, nil})
+		}
+		for _, row := range run.AllRows() {
+			dimensions, err := dimensionsToJson(row.Dimensions)
+			if err != nil {
+				return nil, nil, err
+			}
+			results = append(results, []any{runId, run.FullName, row.Resource, row.Status, row.Reason, dimensions})
+		}
+	}
+	return summary, results, nil
+}
+
+// dimensionsToJson converts the row dimensions to a json object of dimension key to value
+func dimensionsToJson(dimensions []Dimension) (string, error) {
+	dimensionMap := make(map[string]string, len(dimensions))
+	for _, dim := range dimensions {
+		dimensionMap[dim.Key] = dim.Value
+	}
+	jsonBytes, err := json.Marshal(dimensionMap)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonBytes), nil
+}
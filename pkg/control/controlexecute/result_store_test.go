@@ -0,0 +1,76 @@
+package controlexecute
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+)
+
+func TestDimensionsToJson(t *testing.T) {
+	cases := map[string]struct {
+		dimensions []Dimension
+		expected   string
+	}{
+		"no dimensions": {
+			expected: `{}`,
+		},
+		"dimensions": {
+			dimensions: []Dimension{{Key: "region", Value: "us-east-1"}, {Key: "account_id", Value: "123"}},
+			expected:   `{"account_id":"123","region":"us-east-1"}`,
+		},
+		"escaped value": {
+			dimensions: []Dimension{{Key: "name", Value: `my "bucket"`}},
+			expected:   `{"name":"my \"bucket\""}`,
+		},
+	}
+	for name, c := range cases {
+		actual, err := dimensionsToJson(c.dimensions)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err.Error())
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", name, c.expected, actual)
+		}
+	}
+}
+
+func TestCheckResultValues(t *testing.T) {
+	root := &ResultGroup{Summary: NewGroupSummary(), updateLock: new(sync.Mutex)}
+	run := &ControlRun{FullName: "aws_compliance.control.s3_public", Group: root}
+	run.Rows = []*ResultRow{
+		{Run: run, Resource: "bucket_a", Status: constants.ControlAlarm, Reason: "public", Dimensions: []Dimension{{Key: "region", Value: "us-east-1"}}},
+		{Run: run, Resource: "bucket_b", Status: constants.ControlOk, Reason: "private"},
+	}
+	run.Summary = &controlstatus.StatusSummary{Alarm: 1, Ok: 1}
+	errorRun := &ControlRun{FullName: "aws_compliance.control.s3_logging", Group: root, runError: fmt.Errorf("failed"), RunErrorString: "failed"}
+	errorRun.Summary = &controlstatus.StatusSummary{Error: 1}
+
+	// results removed by the result filter are still stored
+	filter, err := NewResultFilter([]string{"ok"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := &ExecutionTree{Root: root, ControlRuns: []*ControlRun{run, errorRun}, ResultFilter: filter}
+	tree.applyResultFilter()
+
+	summary, results, err := tree.checkResultValues("run_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (controlstatus.StatusSummary{Alarm: 1, Ok: 1, Error: 1}); *summary != expected {
+		t.Errorf("expected summary %v, got %v", expected, *summary)
+	}
+	expected := [][]any{
+		{"run_1", "aws_compliance.control.s3_public", "bucket_a", constants.ControlAlarm, "public", `{"region":"us-east-1"}`},
+		{"run_1", "aws_compliance.control.s3_public", "bucket_b", constants.ControlOk, "private", `{}`},
+		{"run_1", "aws_compliance.control.s3_logging", nil, constants.ControlError, "failed", nil},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected results %v, got %v", expected, results)
+	}
+}
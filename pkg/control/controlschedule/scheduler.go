@@ -0,0 +1,112 @@
+package controlschedule
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+	"github.com/turbot/steampipe/pkg/db/db_common"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/pkg/workspace"
+)
+
+// Scheduler executes check runs on the cron schedules defined in the workspace profile,
+// storing the results of each run in the check results tables
+type Scheduler struct {
+	schedules []*modconfig.CheckSchedule
+	workspace *workspace.Workspace
+	client    db_common.Client
+	// the writer to which the outcome of each run is reported
+	output     io.Writer
+	outputLock sync.Mutex
+}
+
+func NewScheduler(ctx context.Context, schedules []*modconfig.CheckSchedule, w *workspace.Workspace, client db_common.Client) (*Scheduler, error) {
+	if len(schedules) == 0 {
+		return nil, fmt.Errorf("no check schedules are defined")
+	}
+	// verify the scheduled benchmarks can be resolved, by building (but not executing) an execution tree for each
+	for _, schedule := range schedules {
+		if _, err := controlexecute.NewExecutionTree(ctx, w, client, schedule.Benchmark, ""); err != nil {
+			return nil, fmt.Errorf("invalid schedule '%s': %s", schedule.ScheduleName, err.Error())
+		}
+	}
+	return &Scheduler{
+		schedules: schedules,
+		workspace: w,
+		client:    client,
+		output:    os.Stdout,
+	}, nil
+}
+
+// Run executes each schedule until the context is cancelled
+// the runs of a schedule never overlap - if a run is still executing when the schedule next fires, that run is skipped
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, schedule := range s.schedules {
+		wg.Add(1)
+		go func(schedule *modconfig.CheckSchedule) {
+			defer wg.Done()
+			s.runSchedule(ctx, schedule)
+		}(schedule)
+	}
+	wg.Wait()
+}
+
+func (s *Scheduler) runSchedule(ctx context.Context, schedule *modconfig.CheckSchedule) {
+	for {
+		next := schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Printf("[WARN] schedule '%s' has no future runs", schedule.ScheduleName)
+			return
+		}
+		s.printf("next run of schedule '%s' (%s) at %s", schedule.ScheduleName, schedule.Benchmark, next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.printf("running schedule '%s'", schedule.ScheduleName)
+		runId, summary, err := s.execute(ctx, schedule)
+		if err != nil {
+			s.printf("run of schedule '%s' failed: %s", schedule.ScheduleName, err.Error())
+			continue
+		}
+		s.printf("run of schedule '%s' complete (ok: %d, alarm: %d, error: %d) - results saved with run id %s",
+			schedule.ScheduleName, summary.Ok, summary.Alarm, summary.Error, runId)
+	}
+}
+
+// printf writes a timestamped line to the scheduler output
+func (s *Scheduler) printf(format string, a ...any) {
+	msg := fmt.Sprintf(format, a...)
+	log.Printf("[TRACE] check scheduler: %s", msg)
+
+	s.outputLock.Lock()
+	defer s.outputLock.Unlock()
+	fmt.Fprintf(s.output, "%s %s\n", time.Now().Format(time.RFC3339), msg)
+}
+
+// execute runs the scheduled benchmark and saves the results
+func (s *Scheduler) execute(ctx context.Context, schedule *modconfig.CheckSchedule) (string, controlstatus.StatusSummary, error) {
+	executionTree, err := controlexecute.NewExecutionTree(ctx, s.workspace, s.client, schedule.Benchmark, "")
+	if err != nil {
+		return "", controlstatus.StatusSummary{}, err
+	}
+	summary := executionTree.Execute(ctx)
+	if ctx.Err() != nil {
+		return "", summary, ctx.Err()
+	}
+	runId, err := executionTree.SaveResults(ctx, schedule.Benchmark, schedule.ScheduleName)
+	return runId, summary, err
+}
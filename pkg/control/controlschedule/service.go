@@ -0,0 +1,152 @@
+package controlschedule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/shirou/gopsutil/process"
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/filepaths"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/pkg/utils"
+)
+
+// SchedulerServiceState is the state of a check scheduler started with the steampipe service
+type SchedulerServiceState struct {
+	Pid int `json:"pid"`
+	// the names of the schedules being executed
+	Schedules []string `json:"schedules"`
+}
+
+func loadServiceStateFile() (*SchedulerServiceState, error) {
+	state := &SchedulerServiceState{}
+	stateBytes, err := os.ReadFile(filepaths.CheckSchedulerStateFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	err = json.Unmarshal(stateBytes, state)
+	return state, err
+}
+
+func (s *SchedulerServiceState) save() error {
+	stateBytes, err := json.MarshalIndent(s, "", " ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepaths.CheckSchedulerStateFilePath(), stateBytes, 0666)
+}
+
+// GetSchedulerServiceState returns the state of the running check scheduler, or nil if it is not running
+func GetSchedulerServiceState() (*SchedulerServiceState, error) {
+	state, err := loadServiceStateFile()
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, nil
+	}
+	pidExists, err := utils.PidExists(state.Pid)
+	if err != nil {
+		return nil, err
+	}
+	if !pidExists {
+		return nil, os.Remove(filepaths.CheckSchedulerStateFilePath())
+	}
+	return state, nil
+}
+
+// StopSchedulerService stops the running check scheduler (if any)
+func StopSchedulerService(ctx context.Context) error {
+	state, err := GetSchedulerServiceState()
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return nil
+	}
+	process, err := process.NewProcessWithContext(ctx, int32(state.Pid))
+	if err != nil {
+		return err
+	}
+	err = process.SendSignalWithContext(ctx, syscall.SIGINT)
+	if err != nil {
+		return err
+	}
+	return os.Remove(filepaths.CheckSchedulerStateFilePath())
+}
+
+// RunForService spawns an execution of the 'steampipe check schedule' command, which executes the given schedules.
+// It is used when starting/restarting the steampipe service with the --check-schedule flag set
+func RunForService(ctx context.Context, schedules []*modconfig.CheckSchedule) (*SchedulerServiceState, error) {
+	if len(schedules) == 0 {
+		return nil, fmt.Errorf("no check schedules are defined in the workspace profile")
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	// stop any previous scheduler
+	if err := StopSchedulerService(ctx); err != nil {
+		return nil, err
+	}
+
+	// NOTE: args must be specified <arg>=<arg val>, as each entry in this array is a separate arg passed to cobra
+	args := []string{
+		"check",
+		"schedule",
+		fmt.Sprintf("--%s=%s", constants.ArgInstallDir, filepaths.SteampipeDir),
+		fmt.Sprintf("--%s=%s", constants.ArgModLocation, viper.GetString(constants.ArgModLocation)),
+		fmt.Sprintf("--%s=true", constants.ArgServiceMode),
+		fmt.Sprintf("--%s=false", constants.ArgInput),
+	}
+	if viper.IsSet(constants.ArgWorkspaceProfile) {
+		args = append(args, fmt.Sprintf("--%s=%s", constants.ArgWorkspaceProfile, viper.GetString(constants.ArgWorkspaceProfile)))
+	}
+	for _, variableArg := range viper.GetStringSlice(constants.ArgVariable) {
+		args = append(args, fmt.Sprintf("--%s=%s", constants.ArgVariable, variableArg))
+	}
+	for _, varFile := range viper.GetStringSlice(constants.ArgVarFile) {
+		args = append(args, fmt.Sprintf("--%s=%s", constants.ArgVarFile, varFile))
+	}
+
+	// the scheduler reports the outcome of each run to its output, which is written to the scheduler log
+	logName := fmt.Sprintf("check-scheduler-%s.log", time.Now().Format("2006-01-02"))
+	logFile, err := os.OpenFile(filepath.Join(filepaths.EnsureLogDir(), logName), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return nil, err
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(self, args...)
+	cmd.Env = os.Environ()
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	// set group pgid attributes on the command to ensure the process is not shutdown when its parent terminates
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid:    true,
+		Foreground: false,
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	state := &SchedulerServiceState{Pid: cmd.Process.Pid}
+	for _, schedule := range schedules {
+		state.Schedules = append(state.Schedules, schedule.ScheduleName)
+	}
+	if err := state.save(); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
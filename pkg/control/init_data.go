@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/control/controldisplay"
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
 	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/filepaths"
 	"github.com/turbot/steampipe/pkg/initialisation"
 	"github.com/turbot/steampipe/pkg/statushooks"
+	"github.com/turbot/steampipe/pkg/steampipeconfig"
 	"github.com/turbot/steampipe/pkg/workspace"
 )
 
@@ -19,6 +23,18 @@ type InitData struct {
 	initialisation.InitData
 	OutputFormatter          controldisplay.Formatter
 	ControlFilterWhereClause string
+	// optional baseline of known results, loaded from the '--baseline' file
+	Baseline *controlexecute.Baseline
+	// optional previous check run to compare with, loaded from the '--compare-to' file
+	PreviousRun *controlexecute.PreviousRun
+	// optional policy determining which alarms cause the run to fail, set from the '--fail-on' and '--max-alarms' args
+	FailureThreshold *controlexecute.FailureThreshold
+	// optional cache of control results, enabled by the '--control-cache' arg
+	ControlCache *controlexecute.ControlCache
+	// optional filter applied to the result rows, set using the '--status' and '--dimension' args
+	ResultFilter *controlexecute.ResultFilter
+	// optional grouping of the results, set using the '--group-by' arg
+	GroupBy *controlexecute.GroupBy
 }
 
 // NewInitData returns a new InitData object
@@ -85,6 +101,56 @@ func NewInitData(ctx context.Context) *InitData {
 
 	i.setControlFilterClause()
 
+	if baselinePath := viper.GetString(constants.ArgBaseline); baselinePath != "" {
+		baseline, err := controlexecute.LoadBaseline(baselinePath)
+		if err != nil {
+			i.Result.Error = err
+			return i
+		}
+		i.Baseline = baseline
+	}
+
+	if previousRunPath := viper.GetString(constants.ArgCompareTo); previousRunPath != "" {
+		previousRun, err := controlexecute.LoadPreviousRun(previousRunPath)
+		if err != nil {
+			i.Result.Error = err
+			return i
+		}
+		i.PreviousRun = previousRun
+	}
+
+	if viper.IsSet(constants.ArgFailOn) || viper.IsSet(constants.ArgMaxAlarms) {
+		failureThreshold, err := controlexecute.NewFailureThreshold(viper.GetString(constants.ArgFailOn), viper.GetInt(constants.ArgMaxAlarms))
+		if err != nil {
+			i.Result.Error = err
+			return i
+		}
+		i.FailureThreshold = failureThreshold
+	}
+
+	if viper.IsSet(constants.ArgStatus) || viper.IsSet(constants.ArgDimension) {
+		resultFilter, err := controlexecute.NewResultFilter(viper.GetStringSlice(constants.ArgStatus), viper.GetStringSlice(constants.ArgDimension))
+		if err != nil {
+			i.Result.Error = err
+			return i
+		}
+		i.ResultFilter = resultFilter
+	}
+
+	if groupBy := viper.GetString(constants.ArgGroupBy); groupBy != "" {
+		g, err := controlexecute.NewGroupBy(groupBy)
+		if err != nil {
+			i.Result.Error = err
+			return i
+		}
+		i.GroupBy = g
+	}
+
+	if viper.GetBool(constants.ArgControlCache) {
+		ttl := time.Duration(viper.GetInt(constants.ArgControlCacheTtl)) * time.Second
+		i.ControlCache = controlexecute.NewControlCache(filepaths.EnsureControlCacheDir(), ttl, steampipeconfig.GlobalConfig.Connections)
+	}
+
 	// initialize
 	i.InitData.Init(ctx, constants.InvokerCheck)
 
@@ -51,6 +51,8 @@ func (r *CheckRun) Initialise(ctx context.Context) {
 		r.SetError(ctx, err)
 		return
 	}
+	// benchmark panels always show the weighted compliance score
+	executionTree.Scoring = true
 	r.controlExecutionTree = executionTree
 	r.Root = executionTree.Root.Children[0]
 }
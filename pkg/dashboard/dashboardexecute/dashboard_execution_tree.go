@@ -126,7 +126,8 @@ func (e *DashboardExecutionTree) Execute(ctx context.Context) {
 	// perform any necessary initialisation
 	// (e.g. check run creates the control execution tree)
 	e.Root.Initialise(cancelCtx)
-	if e.Root.GetError() != nil {
+	if err := e.Root.GetError(); err != nil {
+		e.publishExecutionError(ctx, err)
 		return
 	}
 
@@ -137,6 +138,7 @@ func (e *DashboardExecutionTree) Execute(ctx context.Context) {
 	immutablePanels, err := utils.JsonCloneToMap(panels)
 	if err != nil {
 		e.SetError(ctx, err)
+		e.publishExecutionError(ctx, err)
 		return
 	}
 	workspace.PublishDashboardEvent(ctx, &dashboardevents.ExecutionStarted{
@@ -189,6 +191,16 @@ func (e *DashboardExecutionTree) SetError(ctx context.Context, err error) {
 	e.Root.SetError(ctx, err)
 }
 
+// publishExecutionError sends an ExecutionError event - this is used if the execution fails before it has started,
+// so no ExecutionComplete event will be sent
+func (e *DashboardExecutionTree) publishExecutionError(ctx context.Context, err error) {
+	e.workspace.PublishDashboardEvent(ctx, &dashboardevents.ExecutionError{
+		Error:     err,
+		Session:   e.sessionId,
+		Timestamp: time.Now(),
+	})
+}
+
 // GetName implements DashboardParent
 // use mod short name - this will be the root name for all child runs
 func (e *DashboardExecutionTree) GetName() string {
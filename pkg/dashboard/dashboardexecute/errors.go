@@ -0,0 +1,17 @@
+package dashboardexecute
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/utils"
+)
+
+// MissingInputsError is returned when a dashboard is executed non-interactively without all of its required inputs
+type MissingInputsError struct {
+	MissingInputs []string
+}
+
+func (m MissingInputsError) Error() string {
+	return fmt.Sprintf("missing %s: %s", utils.Pluralize("input", len(m.MissingInputs)), strings.Join(m.MissingInputs, ","))
+}
@@ -4,9 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/turbot/steampipe/pkg/utils"
 	"os"
-	"strings"
 	"sync"
 	"time"
 
@@ -37,7 +35,17 @@ func newDashboardExecutor() *DashboardExecutor {
 
 var Executor = newDashboardExecutor()
 
-func (e *DashboardExecutor) ExecuteDashboard(ctx context.Context, sessionId, dashboardName string, inputs map[string]any, workspace *workspace.Workspace, client db_common.Client) (err error) {
+func (e *DashboardExecutor) ExecuteDashboard(ctx context.Context, sessionId, dashboardName string, inputs map[string]any, workspace *workspace.Workspace, client db_common.Client) error {
+	return e.executeDashboard(ctx, sessionId, dashboardName, inputs, workspace, client, e.interactive)
+}
+
+// ExecuteDashboardBatch executes the dashboard non-interactively, regardless of the executor mode
+// i.e. all inputs required by the dashboard must be provided before execution starts
+func (e *DashboardExecutor) ExecuteDashboardBatch(ctx context.Context, sessionId, dashboardName string, inputs map[string]any, workspace *workspace.Workspace, client db_common.Client) error {
+	return e.executeDashboard(ctx, sessionId, dashboardName, inputs, workspace, client, false)
+}
+
+func (e *DashboardExecutor) executeDashboard(ctx context.Context, sessionId, dashboardName string, inputs map[string]any, workspace *workspace.Workspace, client db_common.Client, interactive bool) (err error) {
 	var executionTree *DashboardExecutionTree
 	defer func() {
 		if err != nil && ctx.Err() != nil {
@@ -65,7 +73,7 @@ func (e *DashboardExecutor) ExecuteDashboard(ctx context.Context, sessionId, das
 
 	// if inputs must be provided before execution (i.e. this is a batch dashboard execution),
 	// verify all required inputs are provided
-	if err = e.validateInputs(executionTree, inputs); err != nil {
+	if err = e.validateInputs(executionTree, inputs, interactive); err != nil {
 		return err
 	}
 
@@ -84,8 +92,8 @@ func (e *DashboardExecutor) ExecuteDashboard(ctx context.Context, sessionId, das
 
 // if inputs must be provided before execution (i.e. this is a batch dashboard execution),
 // verify all required inputs are provided
-func (e *DashboardExecutor) validateInputs(executionTree *DashboardExecutionTree, inputs map[string]any) error {
-	if e.interactive {
+func (e *DashboardExecutor) validateInputs(executionTree *DashboardExecutionTree, inputs map[string]any, interactive bool) error {
+	if interactive {
 		// interactive dashboard execution - no need to validate
 		return nil
 	}
@@ -95,8 +103,8 @@ func (e *DashboardExecutor) validateInputs(executionTree *DashboardExecutionTree
 			missingInputs = append(missingInputs, inputName)
 		}
 	}
-	if missingCount := len(missingInputs); missingCount > 0 {
-		return fmt.Errorf("%s '%s' must be provided using '--dashboard-input name=value'", utils.Pluralize("input", missingCount), strings.Join(missingInputs, ","))
+	if len(missingInputs) > 0 {
+		return MissingInputsError{MissingInputs: missingInputs}
 	}
 
 	return nil
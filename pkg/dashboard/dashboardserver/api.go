@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/static"
@@ -14,10 +15,9 @@ import (
 	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/error_helpers"
 	"github.com/turbot/steampipe/pkg/filepaths"
-	"gopkg.in/olahol/melody.v1"
 )
 
-func startAPIAsync(ctx context.Context, webSocket *melody.Melody) chan struct{} {
+func startAPIAsync(ctx context.Context, server *Server) chan struct{} {
 	doneChan := make(chan struct{})
 
 	go func() {
@@ -31,10 +31,17 @@ func startAPIAsync(ctx context.Context, webSocket *melody.Melody) chan struct{}
 		router.Use(static.Serve("/", static.LocalFile(assetsDirectory, true)))
 
 		router.GET("/ws", func(c *gin.Context) {
-			webSocket.HandleRequest(c.Writer, c.Request)
+			server.webSocket.HandleRequest(c.Writer, c.Request)
 		})
 
+		server.addAPIRoutes(ctx, router)
+
 		router.NoRoute(func(c *gin.Context) {
+			// unknown REST API paths are not routed to the UI
+			if strings.HasPrefix(c.Request.URL.Path, apiPathPrefix+"/") {
+				apiError(c, http.StatusNotFound, fmt.Errorf("%s not found", c.Request.URL.Path))
+				return
+			}
 			// https://stackoverflow.com/questions/49547/how-do-we-control-web-page-caching-across-all-browsers
 			c.Header("Cache-Control", "no-cache, no-store, must-revalidate") // HTTP 1.1.
 			c.Header("Pragma", "no-cache")                                   // HTTP 1.0.
@@ -78,7 +78,11 @@ func addBenchmarkChildren(benchmark *modconfig.Benchmark, recordTrunk bool, trun
 }
 
 func buildAvailableDashboardsPayload(workspaceResources *modconfig.ResourceMaps) ([]byte, error) {
+	return json.Marshal(buildAvailableDashboards(workspaceResources))
+}
 
+// buildAvailableDashboards builds the dashboards, benchmarks and snapshots available in the workspace
+func buildAvailableDashboards(workspaceResources *modconfig.ResourceMaps) AvailableDashboardsPayload {
 	payload := AvailableDashboardsPayload{
 		Action:     "available_dashboards",
 		Dashboards: make(map[string]ModAvailableDashboard),
@@ -145,7 +149,7 @@ func buildAvailableDashboardsPayload(workspaceResources *modconfig.ResourceMaps)
 		}
 	}
 
-	return json.Marshal(payload)
+	return payload
 }
 
 func buildWorkspaceErrorPayload(e *dashboardevents.WorkspaceError) ([]byte, error) {
@@ -0,0 +1,196 @@
+package dashboardserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardtypes"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+const (
+	apiPathPrefix = "/api/v1"
+	// the session id of a REST API execution is the execution id with this prefix
+	apiSessionPrefix = "api-"
+	// the maximum number of REST API executions which are retained - once this is exceeded,
+	// the oldest finished executions are removed
+	maxAPIExecutions = 100
+)
+
+// addAPIRoutes adds the routes of the versioned REST API, which allows dashboards to be executed headlessly
+//
+//	GET  /api/v1/dashboards                              list the available dashboards and benchmarks
+//	POST /api/v1/dashboards/{name}/executions            execute a dashboard, with the inputs given in the request body
+//	GET  /api/v1/dashboards/{name}/executions/{id}       get the status of an execution, and the snapshot once it is complete
+func (s *Server) addAPIRoutes(ctx context.Context, router *gin.Engine) {
+	api := router.Group(apiPathPrefix)
+	api.GET("/dashboards", s.handleAPIListDashboards)
+	// executions are started using the server context, as they outlive the request
+	api.POST("/dashboards/:name/executions", func(c *gin.Context) {
+		s.handleAPIExecuteDashboard(ctx, c)
+	})
+	api.GET("/dashboards/:name/executions/:id", s.handleAPIGetExecution)
+}
+
+func (s *Server) handleAPIListDashboards(c *gin.Context) {
+	availableDashboards := buildAvailableDashboards(s.workspace.GetResourceMaps())
+	c.JSON(http.StatusOK, APIDashboardsResponse{
+		Dashboards: availableDashboards.Dashboards,
+		Benchmarks: availableDashboards.Benchmarks,
+	})
+}
+
+func (s *Server) handleAPIExecuteDashboard(ctx context.Context, c *gin.Context) {
+	dashboardName, ok := s.resolveDashboardName(c.Param("name"))
+	if !ok {
+		apiError(c, http.StatusNotFound, fmt.Errorf("dashboard '%s' does not exist in workspace", c.Param("name")))
+		return
+	}
+
+	var request APIExecutionRequest
+	// the request body is optional
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		apiError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %s", err.Error()))
+		return
+	}
+
+	execution := &APIExecution{
+		Id:        uuid.New().String(),
+		Dashboard: dashboardName,
+		Status:    APIExecutionRunning,
+		Inputs:    request.Inputs,
+		StartTime: time.Now(),
+	}
+	// add the execution before starting it, so the completion event cannot be missed
+	s.addAPIExecution(execution)
+
+	// there is no client to provide inputs once the execution has started, so all inputs must be given in the request
+	sessionId := apiSessionPrefix + execution.Id
+	if err := dashboardexecute.Executor.ExecuteDashboardBatch(ctx, sessionId, dashboardName, request.Inputs, s.workspace, s.dbClient); err != nil {
+		s.deleteAPIExecution(execution.Id)
+		apiError(c, http.StatusBadRequest, err)
+		return
+	}
+	log.Printf("[TRACE] REST API execution %s started for %s", execution.Id, dashboardName)
+
+	c.Header("Location", fmt.Sprintf("%s/dashboards/%s/executions/%s", apiPathPrefix, dashboardName, execution.Id))
+	c.JSON(http.StatusCreated, s.getAPIExecution(execution.Id))
+}
+
+func (s *Server) handleAPIGetExecution(c *gin.Context) {
+	execution := s.getAPIExecution(c.Param("id"))
+	// the execution must be for the dashboard in the path
+	if dashboardName, ok := s.resolveDashboardName(c.Param("name")); execution == nil || !ok || execution.Dashboard != dashboardName {
+		apiError(c, http.StatusNotFound, fmt.Errorf("execution '%s' not found for dashboard '%s'", c.Param("id"), c.Param("name")))
+		return
+	}
+	c.JSON(http.StatusOK, execution)
+}
+
+// resolveDashboardName returns the full name of the dashboard or benchmark with the given name
+// if no mod is specified, the workspace mod is assumed
+func (s *Server) resolveDashboardName(name string) (string, bool) {
+	parsedName, err := modconfig.ParseResourceName(name)
+	if err != nil || parsedName.ItemType == "" {
+		return "", false
+	}
+	if parsedName.Mod == "" {
+		parsedName.Mod = s.workspace.Mod.ShortName
+	}
+	fullName := parsedName.ToFullName()
+
+	resourceMaps := s.workspace.GetResourceMaps()
+	switch parsedName.ItemType {
+	case modconfig.BlockTypeDashboard:
+		_, ok := resourceMaps.Dashboards[fullName]
+		return fullName, ok
+	case modconfig.BlockTypeBenchmark:
+		_, ok := resourceMaps.Benchmarks[fullName]
+		return fullName, ok
+	}
+	return "", false
+}
+
+// isAPISession returns whether the session is for a REST API execution (rather than a websocket client)
+func isAPISession(sessionId string) bool {
+	return strings.HasPrefix(sessionId, apiSessionPrefix)
+}
+
+// setAPIExecutionComplete sets the result of the REST API execution for the given API session
+func (s *Server) setAPIExecutionComplete(ctx context.Context, sessionId string, snapshot *dashboardtypes.SteampipeSnapshot, err error) {
+	executionId := strings.TrimPrefix(sessionId, apiSessionPrefix)
+
+	s.apiExecutionLock.Lock()
+	if execution, ok := s.apiExecutions[executionId]; ok {
+		endTime := time.Now()
+		execution.EndTime = &endTime
+		execution.Snapshot = snapshot
+		execution.Status = APIExecutionComplete
+		if err != nil {
+			execution.Status = APIExecutionError
+			execution.Error = err.Error()
+		}
+	}
+	s.apiExecutionLock.Unlock()
+
+	// there is no client session to re-execute the dashboard, so the execution can be removed from the executor
+	dashboardexecute.Executor.CancelExecutionForSession(ctx, sessionId)
+}
+
+func (s *Server) addAPIExecution(execution *APIExecution) {
+	s.apiExecutionLock.Lock()
+	defer s.apiExecutionLock.Unlock()
+
+	s.apiExecutions[execution.Id] = execution
+	s.apiExecutionOrder = append(s.apiExecutionOrder, execution.Id)
+
+	// remove the oldest finished executions
+	for i := 0; i < len(s.apiExecutionOrder) && len(s.apiExecutionOrder) > maxAPIExecutions; {
+		id := s.apiExecutionOrder[i]
+		if s.apiExecutions[id].Status == APIExecutionRunning {
+			i++
+			continue
+		}
+		delete(s.apiExecutions, id)
+		s.apiExecutionOrder = append(s.apiExecutionOrder[:i], s.apiExecutionOrder[i+1:]...)
+	}
+}
+
+func (s *Server) deleteAPIExecution(executionId string) {
+	s.apiExecutionLock.Lock()
+	defer s.apiExecutionLock.Unlock()
+
+	delete(s.apiExecutions, executionId)
+	for i, id := range s.apiExecutionOrder {
+		if id == executionId {
+			s.apiExecutionOrder = append(s.apiExecutionOrder[:i], s.apiExecutionOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// getAPIExecution returns a copy of the execution with the given id, or nil if it does not exist
+func (s *Server) getAPIExecution(executionId string) *APIExecution {
+	s.apiExecutionLock.Lock()
+	defer s.apiExecutionLock.Unlock()
+
+	execution, ok := s.apiExecutions[executionId]
+	if !ok {
+		return nil
+	}
+	res := *execution
+	return &res
+}
+
+func apiError(c *gin.Context, status int, err error) {
+	c.JSON(status, APIErrorResponse{Error: err.Error()})
+}
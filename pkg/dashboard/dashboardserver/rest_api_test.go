@@ -0,0 +1,225 @@
+package dashboardserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/turbot/steampipe/pkg/db/db_common"
+	"github.com/turbot/steampipe/pkg/workspace"
+	"gopkg.in/olahol/melody.v1"
+)
+
+const apiTestMod = `mod "api_test" {
+  title = "api test"
+}
+
+dashboard "text" {
+  text {
+    value = "hello"
+  }
+}
+
+dashboard "with_input" {
+  input "region" {
+    sql = "select 'us-east-1' as label, 'us-east-1' as value"
+  }
+
+  table {
+    sql  = "select $1 as region"
+    args = [self.input.region.value]
+  }
+}
+`
+
+// apiTestClient is a client for dashboards which do not execute any queries
+type apiTestClient struct {
+	db_common.Client
+}
+
+func (c *apiTestClient) GetRequiredSessionSearchPath() []string {
+	return nil
+}
+
+// apiTestExecution is the response for an execution - the snapshot panels cannot be unmarshalled into a SteampipeSnapshot
+type apiTestExecution struct {
+	Id        string             `json:"id"`
+	Dashboard string             `json:"dashboard"`
+	Status    APIExecutionStatus `json:"status"`
+	Error     string             `json:"error"`
+	EndTime   *time.Time         `json:"end_time"`
+	Snapshot  *struct {
+		Panels map[string]any `json:"panels"`
+	} `json:"snapshot"`
+}
+
+func newAPITestServer(t *testing.T) (*Server, *httptest.Server) {
+	logSink = io.Discard
+	gin.SetMode(gin.TestMode)
+
+	modPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(modPath, "mod.sp"), []byte(apiTestMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	w, errAndWarnings := workspace.Load(ctx, modPath)
+	if err := errAndWarnings.GetError(); err != nil {
+		t.Fatalf("failed to load workspace: %s", err.Error())
+	}
+
+	server := &Server{
+		dbClient:         &apiTestClient{},
+		mutex:            &sync.Mutex{},
+		dashboardClients: make(map[string]*DashboardClientInfo),
+		webSocket:        melody.New(),
+		workspace:        w,
+		apiExecutions:    make(map[string]*APIExecution),
+	}
+	w.RegisterDashboardEventHandler(ctx, server.HandleDashboardEvent)
+
+	router := gin.New()
+	server.addAPIRoutes(ctx, router)
+	httpServer := httptest.NewServer(router)
+	t.Cleanup(httpServer.Close)
+	return server, httpServer
+}
+
+func apiTestRequest(t *testing.T, method, url, body string, target any) int {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if target != nil {
+		if err := json.NewDecoder(res.Body).Decode(target); err != nil {
+			t.Fatalf("failed to decode response: %s", err.Error())
+		}
+	}
+	return res.StatusCode
+}
+
+func TestAPIExecuteDashboard(t *testing.T) {
+	_, httpServer := newAPITestServer(t)
+
+	var execution apiTestExecution
+	status := apiTestRequest(t, http.MethodPost, httpServer.URL+"/api/v1/dashboards/dashboard.text/executions", "", &execution)
+	if status != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, status)
+	}
+	if execution.Dashboard != "api_test.dashboard.text" || execution.Status != APIExecutionRunning {
+		t.Fatalf("unexpected execution: %+v", execution)
+	}
+
+	// poll the execution until it completes
+	executionUrl := fmt.Sprintf("%s/api/v1/dashboards/dashboard.text/executions/%s", httpServer.URL, execution.Id)
+	deadline := time.Now().Add(10 * time.Second)
+	for execution.Status == APIExecutionRunning {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for execution to complete")
+		}
+		time.Sleep(20 * time.Millisecond)
+		if status := apiTestRequest(t, http.MethodGet, executionUrl, "", &execution); status != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, status)
+		}
+	}
+
+	if execution.Status != APIExecutionComplete {
+		t.Fatalf("expected status %s, got %s (%s)", APIExecutionComplete, execution.Status, execution.Error)
+	}
+	if execution.EndTime == nil || execution.Snapshot == nil {
+		t.Fatalf("expected a complete execution to have an end time and snapshot")
+	}
+	if _, ok := execution.Snapshot.Panels["api_test.dashboard.text"]; !ok {
+		t.Errorf("expected the snapshot to contain the dashboard panel")
+	}
+}
+
+func TestAPIExecuteDashboardErrors(t *testing.T) {
+	server, httpServer := newAPITestServer(t)
+
+	cases := map[string]struct {
+		path           string
+		body           string
+		expectedStatus int
+		expectedError  string
+	}{
+		"unknown dashboard": {
+			path:           "/api/v1/dashboards/dashboard.unknown/executions",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "dashboard 'dashboard.unknown' does not exist in workspace",
+		},
+		"missing input": {
+			path:           "/api/v1/dashboards/dashboard.with_input/executions",
+			body:           `{"inputs": {}}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "missing input: input.region",
+		},
+		"invalid body": {
+			path:           "/api/v1/dashboards/dashboard.text/executions",
+			body:           `{"inputs": [`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid request body",
+		},
+	}
+	for name, c := range cases {
+		var res APIErrorResponse
+		status := apiTestRequest(t, http.MethodPost, httpServer.URL+c.path, c.body, &res)
+		if status != c.expectedStatus {
+			t.Errorf("%s: expected status %d, got %d", name, c.expectedStatus, status)
+		}
+		if !strings.HasPrefix(res.Error, c.expectedError) {
+			t.Errorf("%s: expected error '%s', got '%s'", name, c.expectedError, res.Error)
+		}
+	}
+
+	// failed executions are not retained
+	if len(server.apiExecutions) != 0 {
+		t.Errorf("expected no executions, got %d", len(server.apiExecutions))
+	}
+}
+
+func TestAPIExecutionEviction(t *testing.T) {
+	server, httpServer := newAPITestServer(t)
+
+	// fill the execution list - the oldest execution is still running
+	for i := 0; i < maxAPIExecutions; i++ {
+		status := APIExecutionComplete
+		if i == 0 {
+			status = APIExecutionRunning
+		}
+		server.addAPIExecution(&APIExecution{Id: fmt.Sprintf("execution_%d", i), Dashboard: "api_test.dashboard.text", Status: status})
+	}
+
+	var execution apiTestExecution
+	if status := apiTestRequest(t, http.MethodPost, httpServer.URL+"/api/v1/dashboards/dashboard.text/executions", "", &execution); status != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, status)
+	}
+
+	// the oldest finished execution is removed, running executions are retained
+	expectedStatus := map[string]int{
+		"execution_0": http.StatusOK,
+		"execution_1": http.StatusNotFound,
+		"execution_2": http.StatusOK,
+		execution.Id:  http.StatusOK,
+	}
+	for id, expected := range expectedStatus {
+		url := fmt.Sprintf("%s/api/v1/dashboards/dashboard.text/executions/%s", httpServer.URL, id)
+		if status := apiTestRequest(t, http.MethodGet, url, "", nil); status != expected {
+			t.Errorf("%s: expected status %d, got %d", id, expected, status)
+		}
+	}
+}
@@ -26,6 +26,11 @@ type Server struct {
 	dashboardClients map[string]*DashboardClientInfo
 	webSocket        *melody.Melody
 	workspace        *workspace.Workspace
+	// executions started using the REST API, keyed by execution id
+	apiExecutions map[string]*APIExecution
+	// the ids of the REST API executions, in the order they were started
+	apiExecutionOrder []string
+	apiExecutionLock  sync.Mutex
 }
 
 func NewServer(ctx context.Context, dbClient db_common.Client, w *workspace.Workspace) (*Server, error) {
@@ -45,6 +50,7 @@ func NewServer(ctx context.Context, dbClient db_common.Client, w *workspace.Work
 		dashboardClients: dashboardClients,
 		webSocket:        webSocket,
 		workspace:        w,
+		apiExecutions:    make(map[string]*APIExecution),
 	}
 
 	w.RegisterDashboardEventHandler(ctx, server.HandleDashboardEvent)
@@ -58,7 +64,7 @@ func NewServer(ctx context.Context, dbClient db_common.Client, w *workspace.Work
 // it returns a channel which is signalled when the API server terminates
 func (s *Server) Start(ctx context.Context) chan struct{} {
 	s.initAsync(ctx)
-	return startAPIAsync(ctx, s.webSocket)
+	return startAPIAsync(ctx, s)
 }
 
 // Shutdown stops the API server
@@ -117,6 +123,9 @@ func (s *Server) HandleDashboardEvent(ctx context.Context, event dashboardevents
 		}
 
 		s.writePayloadToSession(e.Session, payload)
+		if isAPISession(e.Session) {
+			s.setAPIExecutionComplete(ctx, e.Session, nil, e.Error)
+		}
 		OutputError(ctx, e.Error)
 
 	case *dashboardevents.ExecutionComplete:
@@ -127,6 +136,10 @@ func (s *Server) HandleDashboardEvent(ctx context.Context, event dashboardevents
 		}
 		dashboardName := e.Root.GetName()
 		s.writePayloadToSession(e.Session, payload)
+		// only REST API executions store the snapshot - websocket clients build it from the execution complete payload
+		if isAPISession(e.Session) {
+			s.setAPIExecutionComplete(ctx, e.Session, dashboardexecute.ExecutionCompleteToSnapshot(e), e.Root.GetError())
+		}
 		outputReady(ctx, fmt.Sprintf("Execution complete: %s", dashboardName))
 
 	case *dashboardevents.ControlComplete:
@@ -171,3 +171,37 @@ type DashboardMetadataPayload struct {
 	Action   string            `json:"action"`
 	Metadata DashboardMetadata `json:"metadata"`
 }
+
+type APIExecutionStatus string
+
+const (
+	APIExecutionRunning  APIExecutionStatus = "running"
+	APIExecutionComplete APIExecutionStatus = "complete"
+	APIExecutionError    APIExecutionStatus = "error"
+)
+
+// APIExecution is a dashboard execution started using the REST API
+type APIExecution struct {
+	Id        string                 `json:"id"`
+	Dashboard string                 `json:"dashboard"`
+	Status    APIExecutionStatus     `json:"status"`
+	Inputs    map[string]interface{} `json:"inputs,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+	StartTime time.Time              `json:"start_time"`
+	EndTime   *time.Time             `json:"end_time,omitempty"`
+	// the snapshot of the dashboard - set once the execution is complete
+	Snapshot *dashboardtypes.SteampipeSnapshot `json:"snapshot,omitempty"`
+}
+
+type APIDashboardsResponse struct {
+	Dashboards map[string]ModAvailableDashboard `json:"dashboards"`
+	Benchmarks map[string]ModAvailableBenchmark `json:"benchmarks"`
+}
+
+type APIExecutionRequest struct {
+	Inputs map[string]interface{} `json:"inputs"`
+}
+
+type APIErrorResponse struct {
+	Error string `json:"error"`
+}
@@ -384,7 +384,7 @@ func populateRow(columnValues []interface{}, cols []*queryresult.ColumnDef) ([]i
 func isStreamingOutput() bool {
 	outputFormat := viper.GetString(constants.ArgOutput)
 
-	return helpers.StringSliceContains([]string{constants.OutputFormatCSV, constants.OutputFormatLine}, outputFormat)
+	return helpers.StringSliceContains([]string{constants.OutputFormatCSV, constants.OutputFormatLine, constants.OutputFormatJSONL}, outputFormat)
 }
 
 func humanizeRowCount(count int) string {
@@ -42,9 +42,10 @@ func (r *InitResult) DisplayMessages() {
 			error_helpers.ShowWarning(w)
 		}
 	}
-	// do not display message in json or csv output mode
+	// do not display message in json, jsonl, csv or binary output modes
 	output := viper.Get(constants.ArgOutput)
-	if output == constants.OutputFormatJSON || output == constants.OutputFormatCSV {
+	if output == constants.OutputFormatJSON || output == constants.OutputFormatJSONL || output == constants.OutputFormatCSV ||
+		output == constants.OutputFormatParquet || output == constants.OutputFormatArrow {
 		return
 	}
 	for _, w := range r.Warnings {
@@ -0,0 +1,53 @@
+package db_local
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/utils"
+)
+
+// ensureCheckResultsSchema creates the schema and tables used to store the results of check runs
+// and grants the steampipe users permission to query, insert and delete results
+func ensureCheckResultsSchema(ctx context.Context) error {
+	utils.LogTime("db.ensureCheckResultsSchema start")
+	defer utils.LogTime("db.ensureCheckResultsSchema end")
+
+	runTable := fmt.Sprintf("%s.%s", constants.CheckResultsSchema, constants.CheckRunTable)
+	resultTable := fmt.Sprintf("%s.%s", constants.CheckResultsSchema, constants.CheckResultTable)
+
+	queries := []string{
+		"lock table pg_namespace;",
+		fmt.Sprintf(`create schema if not exists %s;`, constants.CheckResultsSchema),
+		fmt.Sprintf(`grant usage on schema %s to %s;`, constants.CheckResultsSchema, constants.DatabaseUsersRole),
+		// schedule is the name of the schedule which ran the check (null for runs which were not scheduled)
+		fmt.Sprintf(`create table if not exists %s (
+	run_id text primary key,
+	schedule text,
+	target text not null,
+	start_time timestamptz not null,
+	end_time timestamptz not null,
+	ok integer not null,
+	alarm integer not null,
+	info integer not null,
+	skip integer not null,
+	error integer not null,
+	suppressed integer not null
+);`, runTable),
+		fmt.Sprintf(`create table if not exists %s (
+	run_id text not null references %s (run_id) on delete cascade,
+	control text not null,
+	resource text,
+	status text not null,
+	reason text,
+	dimensions jsonb
+);`, resultTable, runTable),
+		fmt.Sprintf(`create index if not exists %s_run_id_idx on %s (run_id);`, constants.CheckResultTable, resultTable),
+		fmt.Sprintf(`grant select, insert, delete on %s, %s to %s;`, runTable, resultTable, constants.DatabaseUsersRole),
+	}
+	if _, err := executeSqlAsRoot(ctx, queries...); err != nil {
+		return err
+	}
+	return nil
+}
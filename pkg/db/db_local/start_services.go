@@ -152,6 +152,12 @@ func postServiceStart(ctx context.Context) *modconfig.ErrorAndWarnings {
 		return modconfig.NewErrorsAndWarning(err)
 	}
 
+	// create the tables used to store check run results
+	statushooks.SetStatus(ctx, "Setting up check results store")
+	if err := ensureCheckResultsSchema(ctx); err != nil {
+		return modconfig.NewErrorsAndWarning(err)
+	}
+
 	statushooks.SetStatus(ctx, "Setting up functions")
 	if err := refreshFunctions(ctx); err != nil {
 		return modconfig.NewErrorsAndWarning(err)
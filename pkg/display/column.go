@@ -20,7 +20,15 @@ func ColumnNames(columns []*queryresult.ColumnDef) []string {
 	return colNames
 }
 
-type columnValueSettings struct{ nullString string }
+type columnValueSettings struct {
+	nullString string
+	// if set, json values are rendered with indentation
+	prettyJson bool
+	// the layout used to format timestamp and timestamptz values
+	timestampFormat string
+	// if set, timestamptz values are converted to this location before formatting
+	timeLocation *time.Location
+}
 
 type ColumnValueOption func(opt *columnValueSettings)
 
@@ -30,6 +38,22 @@ func WithNullString(nullString string) ColumnValueOption {
 	}
 }
 
+// WithJsonFormat sets the rendering of json values - either constants.JsonFormatPretty or constants.JsonFormatCompact
+func WithJsonFormat(jsonFormat string) ColumnValueOption {
+	return func(opt *columnValueSettings) {
+		opt.prettyJson = jsonFormat == constants.JsonFormatPretty
+	}
+}
+
+// WithTimestampFormat sets the layout used to format timestamp values,
+// and the location which timestamptz values are converted to (if non-nil)
+func WithTimestampFormat(timestampFormat string, location *time.Location) ColumnValueOption {
+	return func(opt *columnValueSettings) {
+		opt.timestampFormat = timestampFormat
+		opt.timeLocation = location
+	}
+}
+
 // ColumnValuesAsString converts a slice of columns into strings
 func ColumnValuesAsString(values []interface{}, columns []*queryresult.ColumnDef, opts ...ColumnValueOption) ([]string, error) {
 	rowAsString := make([]string, len(columns))
@@ -64,15 +88,38 @@ func ColumnValueAsString(val interface{}, col *queryresult.ColumnDef, opts ...Co
 	// possible types for colType are defined in pq/oid/types.go
 	switch col.DataType {
 	case "JSON", "JSONB":
-		bytes, err := json.Marshal(val)
+		var bytes []byte
+		if opt.prettyJson {
+			bytes, err = json.MarshalIndent(val, "", "  ")
+		} else {
+			bytes, err = json.Marshal(val)
+		}
 		if err != nil {
 			return "", err
 		}
 		return string(bytes), nil
+	case "TIMESTAMPTZ":
+		// only apply formatting if a timestamp format or display timezone has been configured
+		t, ok := val.(time.Time)
+		if !ok || (opt.timestampFormat == "" && opt.timeLocation == nil) {
+			return typeHelpers.ToString(val), nil
+		}
+		if opt.timeLocation != nil {
+			t = t.In(opt.timeLocation)
+		}
+		timestampFormat := opt.timestampFormat
+		if timestampFormat == "" {
+			timestampFormat = constants.TimestampTzFormat
+		}
+		return t.Format(timestampFormat), nil
 	case "TIMESTAMP", "DATE", "TIME", "INTERVAL":
 		t, ok := val.(time.Time)
 		if ok {
-			return t.Format("2006-01-02 15:04:05"), nil
+			timestampFormat := constants.TimestampFormat
+			if col.DataType == "TIMESTAMP" && opt.timestampFormat != "" {
+				timestampFormat = opt.timestampFormat
+			}
+			return t.Format(timestampFormat), nil
 		}
 		fallthrough
 	case "NAME":
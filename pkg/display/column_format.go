@@ -0,0 +1,104 @@
+package display
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+// columnFormat is the column display configuration, set either from the query options
+// or using the .columns metaquery
+type columnFormat struct {
+	hidden   map[string]bool
+	widths   map[string]int
+	maxWidth int
+	// the options passed to ColumnValueAsString
+	valueOptions []ColumnValueOption
+}
+
+// getColumnFormat builds the column format from viper
+func getColumnFormat() *columnFormat {
+	res := &columnFormat{
+		hidden:   make(map[string]bool),
+		widths:   make(map[string]int),
+		maxWidth: viper.GetInt(constants.ArgMaxColumnWidth),
+	}
+	if res.maxWidth <= 0 {
+		res.maxWidth = constants.MaxColumnWidth
+	}
+
+	for _, c := range viper.GetStringSlice(constants.ArgHiddenColumns) {
+		if c != "" {
+			res.hidden[c] = true
+		}
+	}
+
+	for _, w := range viper.GetStringSlice(constants.ArgColumnWidths) {
+		if w == "" {
+			continue
+		}
+		column, width, err := ParseColumnWidth(w)
+		if err != nil {
+			log.Printf("[WARN] ignoring invalid column width '%s': %s", w, err.Error())
+			continue
+		}
+		res.widths[column] = width
+	}
+
+	location, err := ParseTimezone(viper.GetString(constants.ArgTimezone))
+	if err != nil {
+		log.Printf("[WARN] ignoring invalid timezone: %s", err.Error())
+	}
+	res.valueOptions = []ColumnValueOption{
+		WithJsonFormat(viper.GetString(constants.ArgJsonFormat)),
+		WithTimestampFormat(viper.GetString(constants.ArgTimestampFormat), location),
+	}
+	return res
+}
+
+func (f *columnFormat) isHidden(column string) bool {
+	return f.hidden[column]
+}
+
+// widthFor returns the maximum display width of the given column
+func (f *columnFormat) widthFor(column string) int {
+	if width, ok := f.widths[column]; ok {
+		return width
+	}
+	return f.maxWidth
+}
+
+// ParseColumnWidth parses a column width of the form "<column>:<width>"
+func ParseColumnWidth(columnWidth string) (string, int, error) {
+	column, widthString, found := strings.Cut(columnWidth, ":")
+	column = strings.TrimSpace(column)
+	if !found || column == "" {
+		return "", 0, fmt.Errorf("column width must be of the form <column>:<width>")
+	}
+	width, err := strconv.Atoi(strings.TrimSpace(widthString))
+	if err != nil || width <= 0 {
+		return "", 0, fmt.Errorf("width of column '%s' must be a positive integer", column)
+	}
+	return column, width, nil
+}
+
+// ParseTimezone returns the location for the given timezone name
+// 'local' (in any case) is the local timezone - an empty name returns a nil location
+func ParseTimezone(timezone string) (*time.Location, error) {
+	switch {
+	case timezone == "":
+		return nil, nil
+	case strings.EqualFold(timezone, "local"):
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone '%s': %s", timezone, err.Error())
+	}
+	return location, nil
+}
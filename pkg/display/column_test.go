@@ -0,0 +1,77 @@
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+func TestColumnValueAsStringFormatting(t *testing.T) {
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone database not available")
+	}
+
+	cases := map[string]struct {
+		val      any
+		dataType string
+		opts     []ColumnValueOption
+		expected string
+	}{
+		"json compact": {
+			val:      map[string]any{"a": 1},
+			dataType: "JSONB",
+			opts:     []ColumnValueOption{WithJsonFormat(constants.JsonFormatCompact)},
+			expected: `{"a":1}`,
+		},
+		"json pretty": {
+			val:      map[string]any{"a": 1},
+			dataType: "JSONB",
+			opts:     []ColumnValueOption{WithJsonFormat(constants.JsonFormatPretty)},
+			expected: "{\n  \"a\": 1\n}",
+		},
+		"timestamp format": {
+			val:      ts,
+			dataType: "TIMESTAMP",
+			opts:     []ColumnValueOption{WithTimestampFormat(time.RFC3339, nil)},
+			expected: "2023-01-02T03:04:05Z",
+		},
+		"timestamptz timezone": {
+			val:      ts,
+			dataType: "TIMESTAMPTZ",
+			opts:     []ColumnValueOption{WithTimestampFormat("", newYork)},
+			expected: "2023-01-01 22:04:05 -05:00",
+		},
+		"date ignores timestamp format": {
+			val:      ts,
+			dataType: "DATE",
+			opts:     []ColumnValueOption{WithTimestampFormat(time.RFC3339, newYork)},
+			expected: "2023-01-02 03:04:05",
+		},
+	}
+
+	for name, c := range cases {
+		actual, err := ColumnValueAsString(c.val, &queryresult.ColumnDef{Name: "c", DataType: c.dataType}, c.opts...)
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s: expected %q, got %q", name, c.expected, actual)
+		}
+	}
+}
+
+func TestParseColumnWidth(t *testing.T) {
+	if column, width, err := ParseColumnWidth("description: 60"); err != nil || column != "description" || width != 60 {
+		t.Errorf("unexpected result: %s, %d, %v", column, width, err)
+	}
+	for _, invalid := range []string{"description", ":60", "description:0", "description:wide"} {
+		if _, _, err := ParseColumnWidth(invalid); err == nil {
+			t.Errorf("expected an error parsing %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package display
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/ipc"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+// recordWriter is implemented by both the parquet and arrow IPC file writers
+type recordWriter interface {
+	Write(rec arrow.Record) error
+	Close() error
+}
+
+// WriteParquet streams the result to w as a parquet file, writing a row group for every
+// constants.ColumnarRowGroupSize rows
+func WriteParquet(ctx context.Context, result *queryresult.Result, w io.Writer) error {
+	schema := ArrowSchema(result.Cols)
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	writer, err := pqarrow.NewFileWriter(schema, w, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return err
+	}
+	return writeRecordBatches(result, schema, writer)
+}
+
+// WriteArrow streams the result to w in the arrow IPC file format, writing a record batch for every
+// constants.ColumnarRowGroupSize rows
+func WriteArrow(ctx context.Context, result *queryresult.Result, w io.Writer) error {
+	schema := ArrowSchema(result.Cols)
+	writer, err := ipc.NewFileWriter(&positionWriter{w: w}, ipc.WithSchema(schema), ipc.WithAllocator(memory.DefaultAllocator))
+	if err != nil {
+		return err
+	}
+	return writeRecordBatches(result, schema, writer)
+}
+
+// positionWriter wraps an io.Writer, tracking the number of bytes written
+// the arrow IPC file writer only seeks to determine the current position, so this allows the
+// arrow file to be written to a writer which does not support seeking (e.g. stdout)
+type positionWriter struct {
+	w   io.Writer
+	pos int64
+}
+
+func (p *positionWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.pos += int64(n)
+	return n, err
+}
+
+func (p *positionWriter) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekCurrent {
+		return 0, fmt.Errorf("positionWriter only supports seeking to the current position")
+	}
+	return p.pos, nil
+}
+
+// ArrowSchema builds an arrow schema from the column definitions of a query result
+func ArrowSchema(cols []*queryresult.ColumnDef) *arrow.Schema {
+	fields := make([]arrow.Field, len(cols))
+	for i, col := range cols {
+		fields[i] = arrow.Field{
+			Name:     col.Name,
+			Type:     arrowDataType(col),
+			Nullable: true,
+		}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// map the postgres data type of the column to an arrow data type
+// types which do not have a native columnar equivalent (json, jsonb, inet, cidr, uuid, interval...)
+// are written as strings, using the same representation as the csv output
+func arrowDataType(col *queryresult.ColumnDef) arrow.DataType {
+	switch col.DataType {
+	case "BOOL":
+		return arrow.FixedWidthTypes.Boolean
+	case "INT2":
+		return arrow.PrimitiveTypes.Int16
+	case "INT4":
+		return arrow.PrimitiveTypes.Int32
+	case "INT8":
+		return arrow.PrimitiveTypes.Int64
+	case "FLOAT4":
+		return arrow.PrimitiveTypes.Float32
+	// NOTE: numeric values are converted to float64 when the rows are read
+	case "FLOAT8", "NUMERIC":
+		return arrow.PrimitiveTypes.Float64
+	case "TIMESTAMP":
+		return &arrow.TimestampType{Unit: arrow.Microsecond}
+	case "TIMESTAMPTZ":
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	case "DATE":
+		return arrow.FixedWidthTypes.Date32
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+// read the rows of the result, building a record batch of (at most) constants.ColumnarRowGroupSize rows
+// and passing each batch to the writer as soon as it is full
+func writeRecordBatches(result *queryresult.Result, schema *arrow.Schema, writer recordWriter) error {
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	var writeErr error
+	batchRows := 0
+	flush := func() {
+		if batchRows == 0 {
+			return
+		}
+		rec := builder.NewRecord()
+		defer rec.Release()
+		batchRows = 0
+		writeErr = writer.Write(rec)
+	}
+
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		// if a write has failed, just drain the remaining rows
+		if writeErr != nil {
+			return
+		}
+		for idx, col := range result.Cols {
+			if err := appendArrowValue(builder.Field(idx), row[idx], col); err != nil {
+				writeErr = err
+				return
+			}
+		}
+		batchRows++
+		if batchRows == constants.ColumnarRowGroupSize {
+			flush()
+		}
+	}
+
+	// write the rows - then write any remaining partial batch
+	err := iterateResults(result, rowFunc)
+	if err == nil && writeErr == nil {
+		flush()
+	}
+	// always close the writer - this writes the file footer
+	closeErr := writer.Close()
+	return error_helpers.CombineErrors(err, writeErr, closeErr)
+}
+
+func appendArrowValue(b array.Builder, val interface{}, col *queryresult.ColumnDef) error {
+	if val == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	switch builder := b.(type) {
+	case *array.BooleanBuilder:
+		v, ok := val.(bool)
+		if !ok {
+			return columnarTypeError(val, col)
+		}
+		builder.Append(v)
+	case *array.Int16Builder:
+		v, err := toInt64(val, math.MinInt16, math.MaxInt16)
+		if err != nil {
+			return columnarTypeError(val, col)
+		}
+		builder.Append(int16(v))
+	case *array.Int32Builder:
+		v, err := toInt64(val, math.MinInt32, math.MaxInt32)
+		if err != nil {
+			return columnarTypeError(val, col)
+		}
+		builder.Append(int32(v))
+	case *array.Int64Builder:
+		v, err := toInt64(val, math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return columnarTypeError(val, col)
+		}
+		builder.Append(v)
+	case *array.Float32Builder:
+		v, err := toFloat64(val)
+		if err != nil {
+			return columnarTypeError(val, col)
+		}
+		builder.Append(float32(v))
+	case *array.Float64Builder:
+		v, err := toFloat64(val)
+		if err != nil {
+			return columnarTypeError(val, col)
+		}
+		builder.Append(v)
+	case *array.TimestampBuilder:
+		t, err := toTime(val)
+		if err != nil {
+			return columnarTypeError(val, col)
+		}
+		builder.Append(arrow.Timestamp(t.UnixMicro()))
+	case *array.Date32Builder:
+		t, err := toTime(val)
+		if err != nil {
+			return columnarTypeError(val, col)
+		}
+		builder.Append(arrow.Date32FromTime(t))
+	case *array.StringBuilder:
+		v, err := ColumnValueAsString(val, col)
+		if err != nil {
+			return err
+		}
+		builder.Append(v)
+	default:
+		return fmt.Errorf("unsupported arrow builder %T for column '%s'", b, col.Name)
+	}
+	return nil
+}
+
+func toInt64(val interface{}, min, max int64) (int64, error) {
+	var res int64
+	switch v := val.(type) {
+	case int:
+		res = int64(v)
+	case int8:
+		res = int64(v)
+	case int16:
+		res = int64(v)
+	case int32:
+		res = int64(v)
+	case int64:
+		res = v
+	case uint8:
+		res = int64(v)
+	case uint16:
+		res = int64(v)
+	case uint32:
+		res = int64(v)
+	case float32:
+		res = int64(v)
+	case float64:
+		// values which have been read from JSON (e.g. snapshots) will be float64
+		res = int64(v)
+	case string:
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		res = i
+	default:
+		return 0, fmt.Errorf("cannot convert %T to an integer", val)
+	}
+	if res < min || res > max {
+		return 0, fmt.Errorf("value %d out of range", res)
+	}
+	return res, nil
+}
+
+func toFloat64(val interface{}) (float64, error) {
+	switch v := val.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to a float", val)
+	}
+}
+
+func toTime(val interface{}) (time.Time, error) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		// values which have been read from JSON (e.g. snapshots) will be RFC3339 strings
+		return time.Parse(time.RFC3339Nano, v)
+	default:
+		return time.Time{}, fmt.Errorf("cannot convert %T to a time", val)
+	}
+}
+
+func columnarTypeError(val interface{}, col *queryresult.ColumnDef) error {
+	return fmt.Errorf("cannot convert value '%v' of column '%s' to %s", val, col.Name, col.DataType)
+}
@@ -0,0 +1,61 @@
+package display
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/export"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+// ColumnarExporter exports a query result to a parquet or arrow IPC file
+type ColumnarExporter struct {
+	export.ExporterBase
+	name      string
+	extension string
+	writeFunc columnarWriterFunc
+}
+
+func NewParquetExporter() *ColumnarExporter {
+	return &ColumnarExporter{
+		name:      constants.OutputFormatParquet,
+		extension: constants.ParquetExtension,
+		writeFunc: WriteParquet,
+	}
+}
+
+func NewArrowExporter() *ColumnarExporter {
+	return &ColumnarExporter{
+		name:      constants.OutputFormatArrow,
+		extension: constants.ArrowExtension,
+		writeFunc: WriteArrow,
+	}
+}
+
+func (e *ColumnarExporter) Export(ctx context.Context, input export.ExportSourceData, filePath string) error {
+	// input must be a query result
+	result, ok := input.(*queryresult.Result)
+	if !ok {
+		return fmt.Errorf("%s exporter input must be *queryresult.Result", e.name)
+	}
+
+	// create the output file
+	destination, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer destination.Close()
+
+	// stream the rows into the file
+	return e.writeFunc(ctx, result, destination)
+}
+
+func (e *ColumnarExporter) FileExtension() string {
+	return e.extension
+}
+
+func (e *ColumnarExporter) Name() string {
+	return e.name
+}
@@ -0,0 +1,178 @@
+package display
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/ipc"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+var columnarTestCols = []*queryresult.ColumnDef{
+	{Name: "bool_col", DataType: "BOOL"},
+	{Name: "int2_col", DataType: "INT2"},
+	{Name: "int4_col", DataType: "INT4"},
+	{Name: "int8_col", DataType: "INT8"},
+	{Name: "numeric_col", DataType: "NUMERIC"},
+	{Name: "timestamp_col", DataType: "TIMESTAMP"},
+	{Name: "timestamptz_col", DataType: "TIMESTAMPTZ"},
+	{Name: "date_col", DataType: "DATE"},
+	{Name: "jsonb_col", DataType: "JSONB"},
+	{Name: "text_col", DataType: "TEXT"},
+}
+
+func TestColumnarRoundTrip(t *testing.T) {
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 123456000, time.UTC)
+	date := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	rows := [][]interface{}{
+		{true, int16(2), int32(4), int64(8), 1.5, ts, ts, date, map[string]interface{}{"a": []interface{}{1, 2}}, "text"},
+		// a row of nulls
+		make([]interface{}, len(columnarTestCols)),
+	}
+
+	cases := map[string]struct {
+		write func(context.Context, *queryresult.Result, *bytes.Buffer) error
+		read  func(*testing.T, []byte) arrow.Record
+	}{
+		"parquet": {
+			write: func(ctx context.Context, result *queryresult.Result, buf *bytes.Buffer) error {
+				return WriteParquet(ctx, result, buf)
+			},
+			read: readParquetRecord,
+		},
+		"arrow": {
+			write: func(ctx context.Context, result *queryresult.Result, buf *bytes.Buffer) error {
+				// bytes.Buffer does not support seeking, so this also tests writing to a non-seekable output
+				return WriteArrow(ctx, result, buf)
+			},
+			read: readArrowRecord,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := c.write(context.Background(), streamTestResult(columnarTestCols, rows), &buf); err != nil {
+				t.Fatalf("write failed: %s", err)
+			}
+			rec := c.read(t, buf.Bytes())
+			defer rec.Release()
+
+			if rec.NumRows() != int64(len(rows)) {
+				t.Fatalf("expected %d rows, got %d", len(rows), rec.NumRows())
+			}
+			expectedTypes := []arrow.Type{arrow.BOOL, arrow.INT16, arrow.INT32, arrow.INT64, arrow.FLOAT64, arrow.TIMESTAMP, arrow.TIMESTAMP, arrow.DATE32, arrow.STRING, arrow.STRING}
+			for i, expected := range expectedTypes {
+				if actual := rec.Column(i).DataType().ID(); actual != expected {
+					t.Errorf("column %s: expected type %s, got %s", columnarTestCols[i].Name, expected, actual)
+				}
+				// the second row is all nulls
+				if !rec.Column(i).IsNull(1) {
+					t.Errorf("column %s: expected null in row 2", columnarTestCols[i].Name)
+				}
+			}
+
+			if v := rec.Column(0).(*array.Boolean).Value(0); v != true {
+				t.Errorf("bool_col: expected true, got %v", v)
+			}
+			if v := rec.Column(1).(*array.Int16).Value(0); v != 2 {
+				t.Errorf("int2_col: expected 2, got %d", v)
+			}
+			if v := rec.Column(2).(*array.Int32).Value(0); v != 4 {
+				t.Errorf("int4_col: expected 4, got %d", v)
+			}
+			if v := rec.Column(3).(*array.Int64).Value(0); v != 8 {
+				t.Errorf("int8_col: expected 8, got %d", v)
+			}
+			if v := rec.Column(4).(*array.Float64).Value(0); v != 1.5 {
+				t.Errorf("numeric_col: expected 1.5, got %v", v)
+			}
+			for _, idx := range []int{5, 6} {
+				if unit := rec.Column(idx).DataType().(*arrow.TimestampType).Unit; unit != arrow.Microsecond {
+					t.Errorf("%s: expected microsecond unit, got %s", columnarTestCols[idx].Name, unit)
+				}
+				if v := rec.Column(idx).(*array.Timestamp).Value(0); v != arrow.Timestamp(ts.UnixMicro()) {
+					t.Errorf("%s: expected %d, got %d", columnarTestCols[idx].Name, ts.UnixMicro(), v)
+				}
+			}
+			if v := rec.Column(7).(*array.Date32).Value(0); v != arrow.Date32FromTime(date) {
+				t.Errorf("date_col: expected %d, got %d", arrow.Date32FromTime(date), v)
+			}
+			if v := rec.Column(8).(*array.String).Value(0); v != `{"a":[1,2]}` {
+				t.Errorf("jsonb_col: expected %s, got %s", `{"a":[1,2]}`, v)
+			}
+			if v := rec.Column(9).(*array.String).Value(0); v != "text" {
+				t.Errorf("text_col: expected text, got %s", v)
+			}
+		})
+	}
+}
+
+func TestColumnarTypeError(t *testing.T) {
+	cols := []*queryresult.ColumnDef{{Name: "int2_col", DataType: "INT2"}}
+	err := WriteArrow(context.Background(), streamTestResult(cols, [][]interface{}{{int64(100000)}}), &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("expected an error writing an out of range INT2 value")
+	}
+}
+
+// streamTestResult returns a result which streams the given rows
+func streamTestResult(cols []*queryresult.ColumnDef, rows [][]interface{}) *queryresult.Result {
+	result := queryresult.NewResult(cols)
+	go func() {
+		for _, row := range rows {
+			result.StreamRow(row)
+		}
+		result.Close()
+	}()
+	return result
+}
+
+func readParquetRecord(t *testing.T, data []byte) arrow.Record {
+	pf, err := file.NewParquetReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to open parquet file: %s", err)
+	}
+	reader, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatalf("failed to read parquet file: %s", err)
+	}
+	table, err := reader.ReadTable(context.Background())
+	if err != nil {
+		t.Fatalf("failed to read parquet table: %s", err)
+	}
+	defer table.Release()
+
+	tableReader := array.NewTableReader(table, -1)
+	defer tableReader.Release()
+	if !tableReader.Next() {
+		t.Fatal("parquet file has no rows")
+	}
+	rec := tableReader.Record()
+	rec.Retain()
+	return rec
+}
+
+func readArrowRecord(t *testing.T, data []byte) arrow.Record {
+	reader, err := ipc.NewFileReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to open arrow file: %s", err)
+	}
+	defer reader.Close()
+	if reader.NumRecords() != 1 {
+		t.Fatalf("expected 1 record batch, got %d", reader.NumRecords())
+	}
+	rec, err := reader.Record(0)
+	if err != nil {
+		t.Fatalf("failed to read arrow record: %s", err)
+	}
+	rec.Retain()
+	return rec
+}
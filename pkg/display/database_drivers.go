@@ -0,0 +1,6 @@
+package display
+
+import (
+	// register the (pure go) sqlite driver used by the sqlite exporter
+	_ "modernc.org/sqlite"
+)
@@ -0,0 +1,12 @@
+//go:build duckdb
+
+package display
+
+import (
+	// register the duckdb driver used by the duckdb exporter
+	// this requires cgo, so is only included in builds using the 'duckdb' build tag
+	_ "github.com/marcboeker/go-duckdb"
+)
+
+// duckDBSupported indicates whether the duckdb driver is included in this build
+const duckDBSupported = true
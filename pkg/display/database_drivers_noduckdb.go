@@ -0,0 +1,6 @@
+//go:build !duckdb
+
+package display
+
+// duckDBSupported indicates whether the duckdb driver is included in this build
+const duckDBSupported = false
@@ -0,0 +1,305 @@
+package display
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/export"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+// ExportTimeColumnName is the name of the column added to exported tables to record when each row was exported,
+// so that results from several exports appended to the same table can be distinguished
+const ExportTimeColumnName = "_export_time"
+
+// a query name (as opposed to the sql of an ad-hoc query) is a dot separated resource name, e.g. 'aws_compliance.query.s3_bucket'
+var queryResourceNameRegex = regexp.MustCompile(`^[\w-]+(\.[\w-]+)*$`)
+
+// DatabaseExporter exports query results to a local SQLite or DuckDB database file
+// the result of each query is written to its own table - if the table already exists the rows are appended,
+// so a single file may be used to collect the results of multiple queries and multiple runs
+type DatabaseExporter struct {
+	export.ExporterBase
+	name       string
+	extension  string
+	driverName string
+	// function to map a postgres data type to a column type of the target database
+	columnType func(dataType string) string
+	// does the target database have native timestamp types
+	nativeTimestamps bool
+}
+
+// DatabaseExporters returns the database exporters supported by this build
+// the duckdb exporter is only available in builds using the 'duckdb' build tag
+func DatabaseExporters() []export.Exporter {
+	exporters := []export.Exporter{NewSqliteExporter()}
+	if duckDBSupported {
+		exporters = append(exporters, NewDuckDBExporter())
+	}
+	return exporters
+}
+
+func NewSqliteExporter() *DatabaseExporter {
+	return &DatabaseExporter{
+		name:       constants.OutputFormatSqlite,
+		extension:  constants.SqliteExtension,
+		driverName: "sqlite",
+		columnType: sqliteColumnType,
+	}
+}
+
+func NewDuckDBExporter() *DatabaseExporter {
+	return &DatabaseExporter{
+		name:             constants.OutputFormatDuckDB,
+		extension:        constants.DuckDBExtension,
+		driverName:       "duckdb",
+		columnType:       duckDBColumnType,
+		nativeTimestamps: true,
+	}
+}
+
+func (e *DatabaseExporter) Export(ctx context.Context, input export.ExportSourceData, filePath string) error {
+	// input must be a query result
+	result, ok := input.(*queryresult.Result)
+	if !ok {
+		return fmt.Errorf("%s exporter input must be *queryresult.Result", e.name)
+	}
+	// the duckdb driver is only included in builds using the 'duckdb' build tag
+	if !helpers.StringSliceContains(sql.Drivers(), e.driverName) {
+		return fmt.Errorf("%s export is not supported by this build of steampipe", e.name)
+	}
+
+	db, err := sql.Open(e.driverName, filePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tableName := ExportTableName(result.QueryName)
+	if err := e.ensureTable(ctx, db, tableName, result.Cols); err != nil {
+		return fmt.Errorf("failed to create table '%s' in %s: %s", tableName, filePath, err.Error())
+	}
+	return e.insertRows(ctx, db, tableName, result)
+}
+
+func (e *DatabaseExporter) FileExtension() string {
+	return e.extension
+}
+
+func (e *DatabaseExporter) Name() string {
+	return e.name
+}
+
+// AppendsToExistingFile implements export.AppendingExporter - the rows of each export are added to the existing table
+func (e *DatabaseExporter) AppendsToExistingFile() bool {
+	return true
+}
+
+// ensureTable creates the table for the result if it does not exist
+// if it does exist, any result columns the table does not have are added
+func (e *DatabaseExporter) ensureTable(ctx context.Context, db *sql.DB, tableName string, cols []*queryresult.ColumnDef) error {
+	existingColumns, err := e.getTableColumns(ctx, db, tableName)
+	if err != nil {
+		return err
+	}
+
+	if existingColumns == nil {
+		columnDefs := make([]string, len(cols)+1)
+		for i, col := range cols {
+			columnDefs[i] = fmt.Sprintf("%s %s", quoteIdentifier(col.Name), e.columnType(col.DataType))
+		}
+		columnDefs[len(cols)] = fmt.Sprintf("%s %s", quoteIdentifier(ExportTimeColumnName), e.exportTimeColumnType())
+		_, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (%s)", quoteIdentifier(tableName), strings.Join(columnDefs, ", ")))
+		return err
+	}
+
+	// the table exists - add any missing columns
+	for _, col := range cols {
+		if existingColumns[col.Name] {
+			continue
+		}
+		alterStatement := fmt.Sprintf("alter table %s add column %s %s", quoteIdentifier(tableName), quoteIdentifier(col.Name), e.columnType(col.DataType))
+		if _, err := db.ExecContext(ctx, alterStatement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTableColumns returns the columns of the given table, or nil if the table does not exist
+func (e *DatabaseExporter) getTableColumns(ctx context.Context, db *sql.DB, tableName string) (map[string]bool, error) {
+	query := "select count(*) from information_schema.tables where table_name = ?"
+	if e.driverName == "sqlite" {
+		query = "select count(*) from sqlite_master where type = 'table' and name = ?"
+	}
+	var count int
+	if err := db.QueryRowContext(ctx, query, tableName).Scan(&count); err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("select * from %s limit 0", quoteIdentifier(tableName)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columnNames, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]bool, len(columnNames))
+	for _, c := range columnNames {
+		res[c] = true
+	}
+	return res, nil
+}
+
+// insertRows inserts the rows of the result in a single transaction
+func (e *DatabaseExporter) insertRows(ctx context.Context, db *sql.DB, tableName string, result *queryresult.Result) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	columnNames := make([]string, len(result.Cols)+1)
+	placeholders := make([]string, len(result.Cols)+1)
+	for i, col := range result.Cols {
+		columnNames[i] = quoteIdentifier(col.Name)
+		placeholders[i] = "?"
+	}
+	columnNames[len(result.Cols)] = quoteIdentifier(ExportTimeColumnName)
+	placeholders[len(result.Cols)] = "?"
+
+	insertStatement := fmt.Sprintf("insert into %s (%s) values (%s)", quoteIdentifier(tableName), strings.Join(columnNames, ", "), strings.Join(placeholders, ", "))
+	stmt, err := tx.PrepareContext(ctx, insertStatement)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	exportTime := e.exportTimeValue(time.Now())
+	for row := range *result.RowChan {
+		if row.Error != nil {
+			return row.Error
+		}
+		values := make([]any, len(row.Data)+1)
+		for i, val := range row.Data {
+			values[i], err = e.columnValue(val, result.Cols[i])
+			if err != nil {
+				return err
+			}
+		}
+		values[len(row.Data)] = exportTime
+		if _, err = stmt.ExecContext(ctx, values...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// columnValue converts a column value into a value which can be inserted into the target database
+func (e *DatabaseExporter) columnValue(val any, col *queryresult.ColumnDef) (any, error) {
+	if val == nil {
+		return nil, nil
+	}
+	switch col.DataType {
+	case "JSON", "JSONB":
+		jsonBytes, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		return string(jsonBytes), nil
+	case "BOOL", "INT2", "INT4", "INT8", "FLOAT4", "FLOAT8":
+		return val, nil
+	case "TIMESTAMP", "TIMESTAMPTZ", "DATE":
+		if t, ok := val.(time.Time); ok && e.nativeTimestamps {
+			return t, nil
+		}
+	}
+	return ColumnValueAsString(val, col)
+}
+
+func (e *DatabaseExporter) exportTimeColumnType() string {
+	return e.columnType("TIMESTAMPTZ")
+}
+
+func (e *DatabaseExporter) exportTimeValue(t time.Time) any {
+	if e.nativeTimestamps {
+		return t
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ExportTableName returns the name of the table the result of the given query is exported to
+// for named queries this is the unqualified query name, for ad-hoc queries it is derived from a hash of the sql,
+// so that exporting the same query again appends to the same table
+func ExportTableName(queryName string) string {
+	queryName = strings.TrimSpace(queryName)
+	if queryName == "" {
+		return "query_result"
+	}
+	if queryResourceNameRegex.MatchString(queryName) {
+		parts := strings.Split(queryName, ".")
+		return parts[len(parts)-1]
+	}
+	hash := sha256.Sum256([]byte(queryName))
+	return fmt.Sprintf("query_%s", hex.EncodeToString(hash[:])[:8])
+}
+
+func sqliteColumnType(dataType string) string {
+	switch dataType {
+	case "BOOL", "INT2", "INT4", "INT8":
+		return "integer"
+	case "FLOAT4", "FLOAT8", "NUMERIC":
+		return "real"
+	default:
+		return "text"
+	}
+}
+
+func duckDBColumnType(dataType string) string {
+	switch dataType {
+	case "BOOL":
+		return "boolean"
+	case "INT2":
+		return "smallint"
+	case "INT4":
+		return "integer"
+	case "INT8":
+		return "bigint"
+	case "FLOAT4":
+		return "float"
+	case "FLOAT8", "NUMERIC":
+		return "double"
+	case "DATE":
+		return "date"
+	case "TIMESTAMP":
+		return "timestamp"
+	case "TIMESTAMPTZ":
+		return "timestamptz"
+	default:
+		return "varchar"
+	}
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
@@ -0,0 +1,114 @@
+package display
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+func TestExportTableName(t *testing.T) {
+	cases := map[string]string{
+		"aws_compliance.query.s3_bucket": "s3_bucket",
+		"query.my_query":                 "my_query",
+		"":                               "query_result",
+	}
+	for queryName, expected := range cases {
+		if actual := ExportTableName(queryName); actual != expected {
+			t.Errorf("ExportTableName(%q) = %q, expected %q", queryName, actual, expected)
+		}
+	}
+
+	// ad-hoc queries are named from a hash of the sql, so the same query always maps to the same table
+	sqlTableName := ExportTableName("select * from aws_s3_bucket")
+	if sqlTableName != ExportTableName("select * from aws_s3_bucket") || sqlTableName == ExportTableName("select * from aws_ec2_instance") {
+		t.Errorf("unexpected table name for sql query: %s", sqlTableName)
+	}
+}
+
+func TestSqliteExportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	filePath := filepath.Join(t.TempDir(), "results.sqlite")
+	exporter := NewSqliteExporter()
+
+	cols := []*queryresult.ColumnDef{
+		{Name: "name", DataType: "TEXT"},
+		{Name: "count", DataType: "INT8"},
+		{Name: "data", DataType: "JSONB"},
+	}
+	exportQuery := func(queryName string, cols []*queryresult.ColumnDef, rows [][]interface{}) {
+		result := streamTestResult(cols, rows)
+		result.QueryName = queryName
+		if err := exporter.Export(ctx, result, filePath); err != nil {
+			t.Fatalf("export of %s failed: %s", queryName, err)
+		}
+	}
+	exportQuery("query.first", cols, [][]interface{}{
+		{"a", int64(1), map[string]interface{}{"k": "v"}},
+		{"b", nil, nil},
+	})
+	// export a second query to the same file - this is written to its own table
+	exportQuery("query.second", cols[:1], [][]interface{}{{"c"}})
+	// export the first query again, with an additional column - the rows are appended to the existing table
+	exportQuery("query.first", append(cols, &queryresult.ColumnDef{Name: "extra", DataType: "TEXT"}), [][]interface{}{
+		{"d", int64(2), nil, "x"},
+	})
+
+	db, err := sql.Open("sqlite", filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	readRows := func(query string) []string {
+		rows, err := db.QueryContext(ctx, query)
+		if err != nil {
+			t.Fatalf("%s failed: %s", query, err)
+		}
+		defer rows.Close()
+		var res []string
+		for rows.Next() {
+			var name string
+			var count sql.NullInt64
+			var data, extra sql.NullString
+			if err := rows.Scan(&name, &count, &data, &extra); err != nil {
+				t.Fatal(err)
+			}
+			res = append(res, fmt.Sprintf("%s|%v|%v|%v", name, nullableValue(count.Int64, count.Valid), nullableValue(data.String, data.Valid), nullableValue(extra.String, extra.Valid)))
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	first := readRows(`select name, count, data, extra from "first" order by name`)
+	expectedFirst := []string{`a|1|{"k":"v"}|<nil>`, "b|<nil>|<nil>|<nil>", "d|2|<nil>|x"}
+	if !reflect.DeepEqual(first, expectedFirst) {
+		t.Errorf("table 'first': expected %v, got %v", expectedFirst, first)
+	}
+	second := readRows(`select name, null, null, null from "second"`)
+	if expectedSecond := []string{"c|<nil>|<nil>|<nil>"}; !reflect.DeepEqual(second, expectedSecond) {
+		t.Errorf("table 'second': expected %v, got %v", expectedSecond, second)
+	}
+
+	// every row records when it was exported
+	var missingExportTime int
+	if err := db.QueryRowContext(ctx, fmt.Sprintf(`select count(*) from "first" where %s is null`, quoteIdentifier(ExportTimeColumnName))).Scan(&missingExportTime); err != nil {
+		t.Fatal(err)
+	}
+	if missingExportTime != 0 {
+		t.Errorf("expected all rows to have an export time, %d do not", missingExportTime)
+	}
+}
+
+func nullableValue(val interface{}, valid bool) interface{} {
+	if !valid {
+		return nil
+	}
+	return val
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -34,18 +35,26 @@ func ShowOutput(ctx context.Context, result *queryresult.Result, opts ...Display
 		o(config)
 	}
 
-	switch cmdconfig.Viper().GetString(constants.ArgOutput) {
+	output := cmdconfig.Viper().GetString(constants.ArgOutput)
+	switch output {
 	case constants.OutputFormatJSON:
 		rowErrors = displayJSON(ctx, result)
+	case constants.OutputFormatJSONL:
+		rowErrors = displayJSONL(ctx, result, os.Stdout, config.timing)
 	case constants.OutputFormatCSV:
 		rowErrors = displayCSV(ctx, result)
 	case constants.OutputFormatLine:
 		rowErrors = displayLine(ctx, result)
 	case constants.OutputFormatTable:
 		rowErrors = displayTable(ctx, result)
+	case constants.OutputFormatParquet:
+		rowErrors = displayColumnar(ctx, result, WriteParquet)
+	case constants.OutputFormatArrow:
+		rowErrors = displayColumnar(ctx, result, WriteArrow)
 	}
 
-	if config.timing {
+	// for jsonl output, the timing is written as a trailing record by displayJSONL
+	if config.timing && output != constants.OutputFormatJSONL {
 		fmt.Println(buildTimingString(result))
 	}
 	// return the number of rows that returned errors
@@ -145,7 +154,11 @@ func getColumnSettings(headers []string, rows [][]string, opts *ShowWrappedTable
 func displayLine(ctx context.Context, result *queryresult.Result) int {
 
 	maxColNameLength, rowErrors := 0, 0
+	columnFormat := getColumnFormat()
 	for _, col := range result.Cols {
+		if columnFormat.isHidden(col.Name) {
+			continue
+		}
 		thisLength := utf8.RuneCountInString(col.Name)
 		if thisLength > maxColNameLength {
 			maxColNameLength = thisLength
@@ -155,9 +168,12 @@ func displayLine(ctx context.Context, result *queryresult.Result) int {
 
 	// define a function to display each row
 	rowFunc := func(row []interface{}, result *queryresult.Result) {
-		recordAsString, _ := ColumnValuesAsString(row, result.Cols)
+		recordAsString, _ := ColumnValuesAsString(row, result.Cols, columnFormat.valueOptions...)
 		requiredTerminalColumnsForValuesOfRecord := 0
-		for _, colValue := range recordAsString {
+		for idx, colValue := range recordAsString {
+			if columnFormat.isHidden(result.Cols[idx].Name) {
+				continue
+			}
 			colRequired := getTerminalColumnsRequiredForString(colValue)
 			if requiredTerminalColumnsForValuesOfRecord < colRequired {
 				requiredTerminalColumnsForValuesOfRecord = colRequired
@@ -169,6 +185,9 @@ func displayLine(ctx context.Context, result *queryresult.Result) int {
 
 		fmt.Printf("-[ RECORD %-2d ]%s\n", (itemIdx + 1), strings.Repeat("-", 75))
 		for idx, column := range recordAsString {
+			if columnFormat.isHidden(result.Cols[idx].Name) {
+				continue
+			}
 			lines := strings.Split(column, "\n")
 			if len(lines) == 1 {
 				fmt.Printf(lineFormat, result.Cols[idx].Name, lines[0])
@@ -246,6 +265,74 @@ func displayJSON(ctx context.Context, result *queryresult.Result) int {
 	return rowErrors
 }
 
+// jsonlMetadata is the trailing record written by the jsonl output if timing is enabled
+type jsonlMetadata struct {
+	RowsReturned      int64    `json:"rows_returned"`
+	DurationMs        *float64 `json:"duration_ms,omitempty"`
+	RowsFetched       *int64   `json:"rows_fetched,omitempty"`
+	CachedRowsFetched *int64   `json:"cached_rows_fetched,omitempty"`
+	HydrateCalls      *int64   `json:"hydrate_calls,omitempty"`
+}
+
+func newJSONLMetadata(rowsReturned int64, timingResult *queryresult.TimingResult) *jsonlMetadata {
+	res := &jsonlMetadata{RowsReturned: rowsReturned}
+	if timingResult == nil {
+		return res
+	}
+	durationMs := float64(timingResult.Duration.Microseconds()) / 1000
+	res.DurationMs = &durationMs
+	if timingMetadata := timingResult.Metadata; timingMetadata != nil {
+		res.RowsFetched = &timingMetadata.RowsFetched
+		res.CachedRowsFetched = &timingMetadata.CachedRowsFetched
+		res.HydrateCalls = &timingMetadata.HydrateCalls
+	}
+	return res
+}
+
+// displayJSONL writes each row to w as a JSON object on its own line, as the rows are received
+// if timing is enabled, a trailing record is written containing the timing and scan metadata, keyed by constants.JSONLMetadataKey
+func displayJSONL(ctx context.Context, result *queryresult.Result, w io.Writer, timing bool) int {
+	rowErrors := 0
+	var rowCount int64
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	// define function to write each row
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		record := map[string]interface{}{}
+		for idx, col := range result.Cols {
+			value, _ := ParseJSONOutputColumnValue(row[idx], col)
+			record[col.Name] = value
+		}
+		if err := encoder.Encode(record); err != nil {
+			fmt.Print("Error displaying result as JSON", err)
+		}
+		rowCount++
+	}
+
+	// call this function for each row
+	if err := iterateResults(result, rowFunc); err != nil {
+		error_helpers.ShowError(ctx, err)
+		rowErrors++
+		return rowErrors
+	}
+
+	if timing {
+		// the timing result (if any) is sent before the row channel is closed
+		var timingResult *queryresult.TimingResult
+		select {
+		case timingResult = <-result.TimingResult:
+		default:
+		}
+		metadata := map[string]*jsonlMetadata{constants.JSONLMetadataKey: newJSONLMetadata(rowCount, timingResult)}
+		if err := encoder.Encode(metadata); err != nil {
+			fmt.Print("Error displaying result as JSON", err)
+		}
+	}
+	return rowErrors
+}
+
 func displayCSV(ctx context.Context, result *queryresult.Result) int {
 	rowErrors := 0
 	csvWriter := csv.NewWriter(os.Stdout)
@@ -276,6 +363,18 @@ func displayCSV(ctx context.Context, result *queryresult.Result) int {
 	return rowErrors
 }
 
+type columnarWriterFunc func(ctx context.Context, result *queryresult.Result, w io.Writer) error
+
+// displayColumnar writes the result to stdout using one of the binary columnar formats (parquet or arrow)
+func displayColumnar(ctx context.Context, result *queryresult.Result, writeFunc columnarWriterFunc) int {
+	rowErrors := 0
+	if err := writeFunc(ctx, result, os.Stdout); err != nil {
+		error_helpers.ShowError(ctx, err)
+		rowErrors++
+	}
+	return rowErrors
+}
+
 func displayTable(ctx context.Context, result *queryresult.Result) int {
 	rowErrors := 0
 	// the buffer to put the output data in
@@ -289,13 +388,15 @@ func displayTable(ctx context.Context, result *queryresult.Result) int {
 
 	colConfigs := []table.ColumnConfig{}
 	headers := make(table.Row, len(result.Cols))
+	columnFormat := getColumnFormat()
 
 	for idx, column := range result.Cols {
 		headers[idx] = column.Name
 		colConfigs = append(colConfigs, table.ColumnConfig{
 			Name:     column.Name,
 			Number:   idx + 1,
-			WidthMax: constants.MaxColumnWidth,
+			WidthMax: columnFormat.widthFor(column.Name),
+			Hidden:   columnFormat.isHidden(column.Name),
 		})
 	}
 
@@ -306,7 +407,7 @@ func displayTable(ctx context.Context, result *queryresult.Result) int {
 
 	// define a function to execute for each row
 	rowFunc := func(row []interface{}, result *queryresult.Result) {
-		rowAsString, _ := ColumnValuesAsString(row, result.Cols)
+		rowAsString, _ := ColumnValuesAsString(row, result.Cols, columnFormat.valueOptions...)
 		rowObj := table.Row{}
 		for _, col := range rowAsString {
 			// trim out non-displayable code-points in string
@@ -10,10 +10,11 @@ type displayConfiguration struct {
 }
 
 // NewDisplayConfiguration creates a default configuration with timing set to
-// true if both --timing is true and --output is table
+// true if both --timing is true and --output is table or jsonl
 func NewDisplayConfiguration() *displayConfiguration {
+	output := cmdconfig.Viper().GetString(constants.ArgOutput)
 	timing := cmdconfig.Viper().GetBool(constants.ArgTiming) &&
-		(cmdconfig.Viper().GetString(constants.ArgOutput) == constants.OutputFormatTable)
+		(output == constants.OutputFormatTable || output == constants.OutputFormatJSONL)
 	return &displayConfiguration{
 		timing: timing,
 	}
@@ -0,0 +1,69 @@
+package display
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+func TestDisplayJSONL(t *testing.T) {
+	cols := []*queryresult.ColumnDef{
+		{Name: "name", DataType: "TEXT"},
+		{Name: "count", DataType: "INT8"},
+		{Name: "tags", DataType: "JSONB"},
+	}
+	rows := [][]interface{}{
+		{"a", int64(1), map[string]interface{}{"env": "prod", "html": "<b>"}},
+		{"b", nil, nil},
+		{"c", int64(3), []interface{}{"x", 1.5}},
+	}
+
+	cases := map[string]struct {
+		timing       bool
+		timingResult *queryresult.TimingResult
+		expected     string
+	}{
+		"rows": {
+			expected: `{"count":1,"name":"a","tags":{"env":"prod","html":"<b>"}}
+{"count":null,"name":"b","tags":null}
+{"count":3,"name":"c","tags":["x",1.5]}
+`,
+		},
+		"rows with timing": {
+			timing: true,
+			timingResult: &queryresult.TimingResult{
+				Duration: 1500 * time.Microsecond,
+				Metadata: &queryresult.TimingMetadata{RowsFetched: 3, CachedRowsFetched: 1, HydrateCalls: 2},
+			},
+			expected: `{"count":1,"name":"a","tags":{"env":"prod","html":"<b>"}}
+{"count":null,"name":"b","tags":null}
+{"count":3,"name":"c","tags":["x",1.5]}
+{"_metadata":{"rows_returned":3,"duration_ms":1.5,"rows_fetched":3,"cached_rows_fetched":1,"hydrate_calls":2}}
+`,
+		},
+	}
+
+	for name, c := range cases {
+		result := queryresult.NewResult(cols)
+		if c.timingResult != nil {
+			result.TimingResult <- c.timingResult
+		}
+		go func() {
+			for _, row := range rows {
+				result.StreamRow(row)
+			}
+			result.Close()
+		}()
+
+		var buf bytes.Buffer
+		if rowErrors := displayJSONL(context.Background(), result, &buf, c.timing); rowErrors != 0 {
+			t.Errorf("%s: expected no row errors, got %d", name, rowErrors)
+		}
+		if actual := buf.String(); actual != c.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", name, c.expected, actual)
+		}
+	}
+}
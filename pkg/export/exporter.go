@@ -14,6 +14,12 @@ type Exporter interface {
 	Alias() string
 }
 
+// AppendingExporter is implemented by exporters which add to an existing export file rather than overwriting it,
+// so the output of multiple executions may be exported to the same file
+type AppendingExporter interface {
+	AppendsToExistingFile() bool
+}
+
 type ExporterBase struct{}
 
 func (*ExporterBase) Alias() string {
@@ -157,6 +157,22 @@ func (m *Manager) DoExport(ctx context.Context, targetName string, source Export
 	return expLocation, error_helpers.CombineErrors(errors...)
 }
 
+// IsFixedFileExport returns whether the export arg specifies a file which is overwritten by every export,
+// i.e. a file path (rather than an exporter name, for which a file name is generated from the execution name)
+// for an exporter which does not append to an existing file
+func (m *Manager) IsFixedFileExport(export string) bool {
+	export = strings.TrimSpace(export)
+	if _, ok := m.registeredExporters[export]; ok {
+		return false
+	}
+	e, ok := m.registeredExtensions[path.Ext(export)]
+	if !ok {
+		return false
+	}
+	appendingExporter, ok := e.(AppendingExporter)
+	return !ok || !appendingExporter.AppendsToExistingFile()
+}
+
 func (m *Manager) ValidateExportFormat(exports []string) error {
 	var invalidFormats []string
 	for _, export := range exports {
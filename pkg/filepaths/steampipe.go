@@ -19,6 +19,7 @@ const (
 	databaseRunningInfoFileName  = "steampipe.json"
 	pluginManagerStateFileName   = "plugin_manager.json"
 	dashboardServerStateFileName = "dashboard_service.json"
+	checkSchedulerStateFileName  = "check_scheduler.json"
 	stateFileName                = "update_check.json"
 	legacyStateFileName          = "update-check.json"
 	availableVersionsFileName    = "available_versions.json"
@@ -50,6 +51,11 @@ func EnsureTemplateDir() string {
 	return ensureSteampipeSubDir(filepath.Join("check", "templates"))
 }
 
+// EnsureControlCacheDir returns the path to the control result cache directory (creates if missing)
+func EnsureControlCacheDir() string {
+	return ensureSteampipeSubDir(filepath.Join("check", "cache"))
+}
+
 // EnsurePluginDir returns the path to the plugins directory (creates if missing)
 func EnsurePluginDir() string {
 	return ensureSteampipeSubDir("plugins")
@@ -165,6 +171,10 @@ func DashboardServiceStateFilePath() string {
 	return filepath.Join(EnsureInternalDir(), dashboardServerStateFileName)
 }
 
+func CheckSchedulerStateFilePath() string {
+	return filepath.Join(EnsureInternalDir(), checkSchedulerStateFileName)
+}
+
 func StateFileName() string {
 	return stateFileName
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/turbot/steampipe/pkg/query"
 	"github.com/turbot/steampipe/pkg/query/metaquery"
 	"github.com/turbot/steampipe/pkg/query/queryhistory"
+	"github.com/turbot/steampipe/pkg/query/querysnippets"
 	"github.com/turbot/steampipe/pkg/schema"
 	"github.com/turbot/steampipe/pkg/statushooks"
 	"github.com/turbot/steampipe/pkg/steampipeconfig"
@@ -51,7 +52,10 @@ type InteractiveClient struct {
 	interactiveBuffer       []string
 	interactivePrompt       *prompt.Prompt
 	interactiveQueryHistory *queryhistory.QueryHistory
+	snippets                *querysnippets.Snippets
 	autocompleteOnEmpty     bool
+	// text to populate the prompt with when it is next started (set by the .history metaquery)
+	nextPromptText string
 	// the cancellation function for the active query - may be nil
 	// NOTE: should ONLY be called by cancelActiveQueryIfAny
 	cancelActiveQuery context.CancelFunc
@@ -92,10 +96,15 @@ func newInteractiveClient(ctx context.Context, initData *query.InitData, result
 	if err != nil {
 		return nil, err
 	}
+	snippets, err := querysnippets.Load()
+	if err != nil {
+		return nil, err
+	}
 	c := &InteractiveClient{
 		initData:                initData,
 		promptResult:            result,
 		interactiveQueryHistory: interactiveQueryHistory,
+		snippets:                snippets,
 		interactiveBuffer:       []string{},
 		autocompleteOnEmpty:     false,
 		initResultChan:          make(chan *db_common.InitResult, 1),
@@ -233,6 +242,8 @@ func (c *InteractiveClient) runInteractivePrompt(ctx context.Context) (ret utils
 	completer := func(d prompt.Document) []prompt.Suggest {
 		return c.queryCompleter(d)
 	}
+	initialText := c.nextPromptText
+	c.nextPromptText = ""
 	c.interactivePrompt = prompt.New(
 		callExecutor,
 		completer,
@@ -250,6 +261,7 @@ func (c *InteractiveClient) runInteractivePrompt(ctx context.Context) (ret utils
 		}),
 		prompt.OptionFormatter(c.highlighter.Highlight),
 		prompt.OptionHistory(c.interactiveQueryHistory.Get()),
+		prompt.OptionInitialBufferText(initialText),
 		prompt.OptionInputTextColor(prompt.DefaultColor),
 		prompt.OptionPrefixTextColor(prompt.DefaultColor),
 		prompt.OptionMaxSuggestion(20),
@@ -371,6 +383,8 @@ func (c *InteractiveClient) executor(ctx context.Context, line string) {
 	}
 
 	// we successfully retrieved a query
+	// - getQuery will have pushed it to the history, so get the entry to record the execution result
+	historyEntry := c.interactiveQueryHistory.Peek()
 
 	// create a  context for the execution of the query
 	queryCtx := c.createQueryContext(ctx)
@@ -400,8 +414,12 @@ func (c *InteractiveClient) executor(ctx context.Context, line string) {
 			if cmdconfig.Viper().GetBool(constants.ArgTiming) {
 				display.DisplayErrorTiming(t)
 			}
+			historyEntry.SetResult(time.Since(t), 0, err)
 		} else {
+			result, recordResult := recordHistoryResult(result, historyEntry, t)
 			c.promptResult.Streamer.StreamResult(result)
+			// the result has been displayed - record the execution details in the history
+			recordResult()
 		}
 	}
 
@@ -456,6 +474,16 @@ func (c *InteractiveClient) getQuery(ctx context.Context, line string) *modconfi
 	// expand the buffer out into 'query'
 	queryString := strings.Join(c.interactiveBuffer, "\n")
 
+	// if the query is the name of a saved snippet, execute the snippet query
+	// (named queries are resolved before snippets, so a snippet can never replace a named query)
+	isSnippet := false
+	if !c.workspace().IsQueryProvider(queryString) {
+		if snippetQuery, ok := c.snippets.ResolveQuery(queryString); ok {
+			queryString = snippetQuery
+			isSnippet = true
+		}
+	}
+
 	// in case of a named query call with params, parse the where clause
 	resolvedQuery, queryProvider, err := c.workspace().ResolveQueryAndArgsFromSQLString(queryString)
 	if err != nil {
@@ -467,7 +495,7 @@ func (c *InteractiveClient) getQuery(ctx context.Context, line string) *modconfi
 		error_helpers.ShowError(ctx, err)
 		return nil
 	}
-	isNamedQuery := queryProvider != nil
+	isNamedQuery := queryProvider != nil || isSnippet
 
 	// should we execute?
 	// we will NOT execute if we are in multiline mode, there is no semi-colon
@@ -525,6 +553,11 @@ func (c *InteractiveClient) executeMetaquery(ctx context.Context, query string)
 		Connections: c.initData.ConnectionMap,
 		Prompt:      c.interactivePrompt,
 		ClosePrompt: func() { c.afterClose = AfterPromptCloseExit },
+		History:     c.interactiveQueryHistory,
+		Snippets:    c.snippets,
+		SetPromptText: func(text string) {
+			c.nextPromptText = text
+		},
 	})
 }
 
@@ -576,6 +609,8 @@ func (c *InteractiveClient) queryCompleter(d prompt.Document) []prompt.Suggest {
 		// add all we know that can be the first words
 		// named queries
 		s = append(s, c.querySuggestions...)
+		// saved snippets
+		s = append(s, c.snippetSuggestions()...)
 		// "select"
 		s = append(s, prompt.Suggest{Text: "select", Output: "select"}, prompt.Suggest{Text: "with", Output: "with"})
 		// metaqueries
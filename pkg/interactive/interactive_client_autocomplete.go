@@ -68,6 +68,17 @@ func (c *InteractiveClient) initialiseQuerySuggestions() {
 	c.querySuggestions = res
 }
 
+// snippetSuggestions builds the suggestions for the saved snippets
+// these are built on demand as snippets may be saved during the session
+func (c *InteractiveClient) snippetSuggestions() []prompt.Suggest {
+	var res []prompt.Suggest
+	for _, snippet := range c.snippets.List() {
+		// show the snippet query on a single line
+		res = append(res, c.addSuggestion("snippet", strings.Join(strings.Fields(snippet.Query), " "), snippet.Name))
+	}
+	return res
+}
+
 // initialiseTableSuggestions build a list of schema and table querySuggestions
 func (c *InteractiveClient) initialiseTableSuggestions() {
 
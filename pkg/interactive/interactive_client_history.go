@@ -0,0 +1,54 @@
+package interactive
+
+import (
+	"time"
+
+	"github.com/turbot/steampipe/pkg/query/queryhistory"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+// recordHistoryResult returns a result which streams the rows (and timing) of the given result,
+// counting the rows and capturing the first row error as they pass through
+// it also returns a function which records the execution details in the history entry
+// - this must be called once the returned result has been fully read
+func recordHistoryResult(result *queryresult.Result, entry *queryhistory.HistoryEntry, startTime time.Time) (*queryresult.Result, func()) {
+	res := queryresult.NewResult(result.Cols)
+
+	var rowCount int64
+	var rowErr error
+	var duration time.Duration
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for row := range *result.RowChan {
+			if row.Error != nil {
+				if rowErr == nil {
+					rowErr = row.Error
+				}
+			} else {
+				rowCount++
+			}
+			*res.RowChan <- row
+		}
+		// the timing result (if any) is sent before the row channel is closed
+		select {
+		case timingResult := <-result.TimingResult:
+			if timingResult != nil {
+				duration = timingResult.Duration
+			}
+			res.TimingResult <- timingResult
+		default:
+		}
+		res.Close()
+	}()
+
+	recordResult := func() {
+		<-done
+		if duration == 0 {
+			duration = time.Since(startTime)
+		}
+		entry.SetResult(duration, rowCount, rowErr)
+	}
+	return res, recordResult
+}
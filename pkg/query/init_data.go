@@ -6,10 +6,13 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/display"
 	"github.com/turbot/steampipe/pkg/export"
 	"github.com/turbot/steampipe/pkg/initialisation"
+	"github.com/turbot/steampipe/pkg/query/querysnippets"
 	"github.com/turbot/steampipe/pkg/statushooks"
 	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/parse"
 	"github.com/turbot/steampipe/pkg/workspace"
 )
 
@@ -36,7 +39,12 @@ func NewInitData(ctx context.Context, args []string) *InitData {
 }
 
 func queryExporters() []export.Exporter {
-	return []export.Exporter{&export.SnapshotExporter{}}
+	exporters := []export.Exporter{
+		&export.SnapshotExporter{},
+		display.NewParquetExporter(),
+		display.NewArrowExporter(),
+	}
+	return append(exporters, display.DatabaseExporters()...)
 }
 
 func (i *InitData) Cancel() {
@@ -93,13 +101,31 @@ func (i *InitData) init(ctx context.Context, args []string) {
 	i.Result.AddWarnings(errAndWarnings.Warnings...)
 	i.Workspace = w
 
-	// set max DB connections to 1
-	viper.Set(constants.ArgMaxParallel, 1)
+	// set max DB connections to the number of queries we may execute in parallel (1 unless --parallel is set)
+	maxParallel := 1
+	if parallel := viper.GetInt(constants.ArgParallel); parallel > 1 {
+		maxParallel = parallel
+	}
+	viper.Set(constants.ArgMaxParallel, maxParallel)
 
 	statushooks.SetStatus(ctx, "Resolving arguments")
 
+	// parse any query args passed using --arg
+	runtimeArgs, err := parse.ParseCommandLineArgs(viper.GetStringSlice(constants.ArgArg))
+	if err != nil {
+		i.Result.Error = fmt.Errorf("invalid --%s value: %s", constants.ArgArg, err.Error())
+		return
+	}
+
+	// replace any args which are the names of saved snippets with the snippet query
+	args, err = resolveSnippets(w, args)
+	if err != nil {
+		i.Result.Error = err
+		return
+	}
+
 	// convert the query or sql file arg into an array of executable queries - check names queries in the current workspace
-	resolvedQueries, err := w.GetQueriesFromArgs(args)
+	resolvedQueries, err := w.GetQueriesFromArgs(args, runtimeArgs)
 	if err != nil {
 		i.Result.Error = err
 		return
@@ -110,6 +136,48 @@ func (i *InitData) init(ctx context.Context, args []string) {
 	i.cancelInitialisation = cancel
 	i.Queries = resolvedQueries
 
+	if len(resolvedQueries) > 1 {
+		if err := i.validateMultipleQueryOutput(); err != nil {
+			i.Result.Error = err
+			return
+		}
+	}
+
 	// and call base init
 	i.InitData.Init(ctx, constants.InvokerQuery)
 }
+
+// validateMultipleQueryOutput validates that the output and export args can be used when executing more than one query
+// - the result of each query is exported separately, so cannot be exported to a file which is overwritten by every export
+// - the binary output formats cannot be concatenated, so cannot be used to display the results
+func (i *InitData) validateMultipleQueryOutput() error {
+	if output := viper.GetString(constants.ArgOutput); output == constants.OutputFormatParquet || output == constants.OutputFormatArrow {
+		return fmt.Errorf("cannot use '%s' output when executing more than one query - use '--%s %s' to export each query result to a separate file", output, constants.ArgExport, output)
+	}
+	for _, e := range viper.GetStringSlice(constants.ArgExport) {
+		if i.ExportManager.IsFixedFileExport(e) {
+			return fmt.Errorf("cannot export to file '%s' when executing more than one query - specify the export format rather than a file name to export each query result to a separate file", e)
+		}
+	}
+	return nil
+}
+
+// resolveSnippets replaces any query args which are the names of saved snippets with the snippet query
+// named queries are resolved before snippets, so a snippet can never replace a named query
+func resolveSnippets(w *workspace.Workspace, args []string) ([]string, error) {
+	snippets, err := querysnippets.Load()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, len(args))
+	for idx, arg := range args {
+		res[idx] = arg
+		if w.IsQueryProvider(arg) {
+			continue
+		}
+		if snippetQuery, ok := snippets.ResolveQuery(arg); ok {
+			res[idx] = snippetQuery
+		}
+	}
+	return res, nil
+}
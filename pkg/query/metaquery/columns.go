@@ -0,0 +1,140 @@
+package metaquery
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/pkg/cmdconfig"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/display"
+)
+
+// .columns sub commands
+const (
+	columnsHide            = "hide"
+	columnsShow            = "show"
+	columnsWidth           = "width"
+	columnsMaxWidth        = "max_width"
+	columnsJson            = "json"
+	columnsTimezone        = "timezone"
+	columnsTimestampFormat = "timestamp_format"
+)
+
+// columnsValidator validates the sub command of the .columns metaquery - the sub command arguments are validated by the handler
+func columnsValidator(args []string) ValidationResult {
+	if len(args) == 0 {
+		return ValidationResult{ShouldRun: true}
+	}
+	return validatorFromArgsOf(constants.CmdColumns)(args[:1])
+}
+
+// setColumnFormat shows or updates the column display formatting for this session
+// the formatting may be persisted by setting the corresponding query options in the workspace profile
+func setColumnFormat(_ context.Context, input *HandlerInput) error {
+	args := input.args()
+	if len(args) == 0 {
+		showColumnFormat()
+		return nil
+	}
+	subCommand := strings.ToLower(args[0])
+	args = args[1:]
+	v := cmdconfig.Viper()
+
+	switch subCommand {
+	case columnsHide:
+		if len(args) == 0 {
+			return fmt.Errorf("%s needs at least 1 column name", columnsHide)
+		}
+		hidden := v.GetStringSlice(constants.ArgHiddenColumns)
+		for _, c := range args {
+			if !helpers.StringSliceContains(hidden, c) {
+				hidden = append(hidden, c)
+			}
+		}
+		v.Set(constants.ArgHiddenColumns, hidden)
+	case columnsShow:
+		// with no columns given, show all columns
+		var hidden []string
+		if len(args) > 0 {
+			for _, c := range v.GetStringSlice(constants.ArgHiddenColumns) {
+				if !helpers.StringSliceContains(args, c) {
+					hidden = append(hidden, c)
+				}
+			}
+		}
+		v.Set(constants.ArgHiddenColumns, hidden)
+	case columnsWidth:
+		if len(args) != 2 {
+			return fmt.Errorf("%s needs 2 arguments: <column> <width>", columnsWidth)
+		}
+		column := args[0]
+		width, err := strconv.Atoi(args[1])
+		if err != nil || width < 0 {
+			return fmt.Errorf("column width must be a positive integer (or 0 to remove the column width)")
+		}
+		// remove any existing width for this column
+		var widths []string
+		for _, w := range v.GetStringSlice(constants.ArgColumnWidths) {
+			if c, _, err := display.ParseColumnWidth(w); err == nil && c != column {
+				widths = append(widths, w)
+			}
+		}
+		if width > 0 {
+			widths = append(widths, fmt.Sprintf("%s:%d", column, width))
+		}
+		v.Set(constants.ArgColumnWidths, widths)
+	case columnsMaxWidth:
+		if len(args) != 1 {
+			return fmt.Errorf("%s needs 1 argument", columnsMaxWidth)
+		}
+		width, err := strconv.Atoi(args[0])
+		if err != nil || width <= 0 {
+			return fmt.Errorf("maximum column width must be a positive integer")
+		}
+		v.Set(constants.ArgMaxColumnWidth, width)
+	case columnsJson:
+		validFormats := []string{constants.JsonFormatPretty, constants.JsonFormatCompact}
+		if len(args) != 1 || !helpers.StringSliceContains(validFormats, strings.ToLower(args[0])) {
+			return fmt.Errorf("%s needs 1 argument: %s", columnsJson, strings.Join(validFormats, " or "))
+		}
+		v.Set(constants.ArgJsonFormat, strings.ToLower(args[0]))
+	case columnsTimezone:
+		if len(args) != 1 {
+			return fmt.Errorf("%s needs 1 argument", columnsTimezone)
+		}
+		if _, err := display.ParseTimezone(args[0]); err != nil {
+			return err
+		}
+		v.Set(constants.ArgTimezone, args[0])
+	case columnsTimestampFormat:
+		// the layout may contain spaces, so use the full text following the sub command
+		layout := getQueryFromMetaquery(getQueryFromMetaquery(input.Query))
+		if layout == "" {
+			return fmt.Errorf("%s needs a Go time layout, e.g. %s", columnsTimestampFormat, constants.TimestampFormat)
+		}
+		v.Set(constants.ArgTimestampFormat, layout)
+	}
+	return nil
+}
+
+func showColumnFormat() {
+	v := cmdconfig.Viper()
+	valueOrDefault := func(value, defaultValue string) string {
+		if value == "" {
+			return defaultValue
+		}
+		return value
+	}
+	rows := [][]string{
+		{"hidden columns", valueOrDefault(strings.Join(v.GetStringSlice(constants.ArgHiddenColumns), ", "), "none")},
+		{"column widths", valueOrDefault(strings.Join(v.GetStringSlice(constants.ArgColumnWidths), ", "), "none")},
+		{"max column width", strconv.Itoa(v.GetInt(constants.ArgMaxColumnWidth))},
+		{"json format", v.GetString(constants.ArgJsonFormat)},
+		{"timezone", valueOrDefault(v.GetString(constants.ArgTimezone), "not set")},
+		{"timestamp format", valueOrDefault(v.GetString(constants.ArgTimestampFormat), constants.TimestampFormat)},
+	}
+	fmt.Println(buildTable(rows, false))
+}
@@ -89,9 +89,10 @@ func init() {
 			title:       constants.CmdOutput,
 			handler:     setViperConfigFromArg(constants.ArgOutput),
 			validator:   composeValidator(exactlyNArgs(1), validatorFromArgsOf(constants.CmdOutput)),
-			description: "Set output format: csv, json, table or line",
+			description: "Set output format: csv, json, jsonl, table or line",
 			args: []metaQueryArg{
 				{value: constants.OutputFormatJSON, description: "Set output to JSON"},
+				{value: constants.OutputFormatJSONL, description: "Set output to JSON Lines (one JSON object per row)"},
 				{value: constants.OutputFormatCSV, description: "Set output to CSV"},
 				{value: constants.OutputFormatTable, description: "Set output to Table"},
 				{value: constants.OutputFormatLine, description: "Set output to Line"},
@@ -126,6 +127,52 @@ func init() {
 			description: "View connections, tables & column information",
 			completer:   inspectCompleter,
 		},
+		constants.CmdExplain: {
+			title:       constants.CmdExplain,
+			handler:     explainQuery(false),
+			validator:   atLeastNArgs(1),
+			description: "Show the query plan for a query, including the plugin calls it will make",
+		},
+		constants.CmdAnalyze: {
+			title:       constants.CmdAnalyze,
+			handler:     explainQuery(true),
+			validator:   atLeastNArgs(1),
+			description: "Execute a query and show the query plan with actual row counts and timings",
+		},
+		constants.CmdHistory: {
+			title:       constants.CmdHistory,
+			handler:     showHistory,
+			validator:   atLeastNArgs(0),
+			description: "Search the query history, or load a history entry (by number) into the prompt",
+		},
+		constants.CmdSave: {
+			title:       constants.CmdSave,
+			handler:     saveSnippet,
+			validator:   exactlyNArgs(1),
+			description: "Save the last executed query as a snippet, which can then be run by name",
+		},
+		constants.CmdSnippets: {
+			title:       constants.CmdSnippets,
+			handler:     listSnippets,
+			validator:   noArgs,
+			description: "List saved query snippets",
+		},
+		constants.CmdColumns: {
+			title:       constants.CmdColumns,
+			handler:     setColumnFormat,
+			validator:   columnsValidator,
+			description: "Show or set column display formatting: hidden columns, widths, json rendering and timestamp format",
+			args: []metaQueryArg{
+				{value: columnsHide, description: "Hide the given columns"},
+				{value: columnsShow, description: "Show the given hidden columns (or all columns if none are given)"},
+				{value: columnsWidth, description: "Set the maximum width of a column: <column> <width> (0 to remove)"},
+				{value: columnsMaxWidth, description: "Set the default maximum column width"},
+				{value: columnsJson, description: "Set the rendering of json values: pretty or compact"},
+				{value: columnsTimezone, description: "Set the timezone used to display timestamptz values, e.g. local, UTC or Europe/London"},
+				{value: columnsTimestampFormat, description: "Set the Go time layout used to display timestamp values"},
+			},
+			completer: completerFromArgsOf(constants.CmdColumns),
+		},
 		constants.CmdConnections: {
 			title:       constants.CmdConnections,
 			handler:     listConnections,
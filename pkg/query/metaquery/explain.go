@@ -0,0 +1,188 @@
+package metaquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+	"github.com/turbot/steampipe/pkg/steampipeconfig"
+	"github.com/xlab/treeprint"
+)
+
+const foreignScanNodeType = "Foreign Scan"
+
+// explainOutput is a single element of the output of 'EXPLAIN (FORMAT JSON)'
+type explainOutput struct {
+	Plan          *planNode `json:"Plan"`
+	PlanningTime  *float64  `json:"Planning Time"`
+	ExecutionTime *float64  `json:"Execution Time"`
+}
+
+// planNode is a node of the postgres query plan
+type planNode struct {
+	NodeType     string      `json:"Node Type"`
+	RelationName string      `json:"Relation Name"`
+	Schema       string      `json:"Schema"`
+	Alias        string      `json:"Alias"`
+	StartupCost  float64     `json:"Startup Cost"`
+	TotalCost    float64     `json:"Total Cost"`
+	PlanRows     float64     `json:"Plan Rows"`
+	ActualRows   *float64    `json:"Actual Rows"`
+	ActualLoops  *float64    `json:"Actual Loops"`
+	ActualTime   *float64    `json:"Actual Total Time"`
+	Filter       string      `json:"Filter"`
+	JoinFilter   string      `json:"Join Filter"`
+	IndexCond    string      `json:"Index Cond"`
+	HashCond     string      `json:"Hash Cond"`
+	Plans        []*planNode `json:"Plans"`
+}
+
+func (n *planNode) label() string {
+	var b strings.Builder
+	b.WriteString(n.NodeType)
+	if n.RelationName != "" {
+		b.WriteString(" on ")
+		if n.Schema != "" {
+			b.WriteString(n.Schema + ".")
+		}
+		b.WriteString(n.RelationName)
+		if n.Alias != "" && n.Alias != n.RelationName {
+			b.WriteString(" " + n.Alias)
+		}
+	}
+	fmt.Fprintf(&b, " (rows=%.0f cost=%.2f..%.2f)", n.PlanRows, n.StartupCost, n.TotalCost)
+	if n.ActualRows != nil {
+		fmt.Fprintf(&b, " (actual rows=%.0f", *n.ActualRows)
+		if n.ActualLoops != nil {
+			fmt.Fprintf(&b, " loops=%.0f", *n.ActualLoops)
+		}
+		if n.ActualTime != nil {
+			fmt.Fprintf(&b, " time=%.3fms", *n.ActualTime)
+		}
+		b.WriteString(")")
+	}
+	if n.NodeType == foreignScanNodeType {
+		return constants.Bold(b.String()).String()
+	}
+	return b.String()
+}
+
+// explainQuery returns a handler which shows the query plan of the sql following the metaquery command
+// if analyze is set, the query is executed and the actual row counts and timings are also shown
+func explainQuery(analyze bool) handler {
+	return func(ctx context.Context, input *HandlerInput) error {
+		query := getQueryFromMetaquery(input.Query)
+
+		options := "FORMAT JSON, VERBOSE"
+		if analyze {
+			options = "ANALYZE, " + options
+		}
+		res, err := input.Client.ExecuteSync(ctx, fmt.Sprintf("EXPLAIN (%s) %s", options, query))
+		if err != nil {
+			return err
+		}
+
+		plans, err := parseExplainResult(res)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range plans {
+			fmt.Println(buildPlanTree(p.Plan, input.Connections).String())
+			if p.PlanningTime != nil {
+				fmt.Printf("Planning time: %.3fms\n", *p.PlanningTime)
+			}
+			if p.ExecutionTime != nil {
+				fmt.Printf("Execution time: %.3fms\n", *p.ExecutionTime)
+			}
+		}
+		fmt.Println()
+		return nil
+	}
+}
+
+// getQueryFromMetaquery returns the text following the metaquery command, with any trailing ';' removed
+func getQueryFromMetaquery(metaquery string) string {
+	query := strings.TrimSpace(metaquery)
+	if idx := strings.IndexAny(query, " \t\n"); idx != -1 {
+		query = query[idx:]
+	} else {
+		query = ""
+	}
+	return strings.TrimSuffix(strings.TrimSpace(query), ";")
+}
+
+// parseExplainResult extracts the plans from the single json row returned by 'EXPLAIN (FORMAT JSON)'
+func parseExplainResult(res *queryresult.SyncQueryResult) ([]explainOutput, error) {
+	if len(res.Rows) == 0 {
+		return nil, fmt.Errorf("explain returned no plan")
+	}
+	row, ok := res.Rows[0].(*queryresult.RowResult)
+	if !ok || len(row.Data) == 0 {
+		return nil, fmt.Errorf("explain returned an unexpected result")
+	}
+	if row.Error != nil {
+		return nil, row.Error
+	}
+
+	// the plan will already have been unmarshalled by the driver - convert it back to json
+	// so we can unmarshal into our plan structs
+	var planJson []byte
+	switch p := row.Data[0].(type) {
+	case string:
+		planJson = []byte(p)
+	case []byte:
+		planJson = p
+	default:
+		var err error
+		planJson, err = json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var plans []explainOutput
+	if err := json.Unmarshal(planJson, &plans); err != nil {
+		return nil, fmt.Errorf("failed to parse query plan: %s", err.Error())
+	}
+	return plans, nil
+}
+
+// buildPlanTree builds a tree from the plan, highlighting foreign scans (i.e. plugin calls)
+// along with the connection and plugin they use and the quals which are passed to the plugin
+func buildPlanTree(root *planNode, connections steampipeconfig.ConnectionDataMap) treeprint.Tree {
+	tree := treeprint.NewWithRoot(root.label())
+	addPlanNodeDetails(tree, root, connections)
+	return tree
+}
+
+func addPlanNodeDetails(tree treeprint.Tree, node *planNode, connections steampipeconfig.ConnectionDataMap) {
+	if node.NodeType == foreignScanNodeType {
+		if connectionData, ok := connections[node.Schema]; ok {
+			tree.AddMetaNode("connection", fmt.Sprintf("%s (plugin: %s)", node.Schema, connectionData.Plugin))
+		}
+		// all restriction quals of a foreign scan are passed to the plugin
+		// - the plugin uses those which match its key columns to limit the api calls it makes
+		if node.Filter != "" {
+			tree.AddMetaNode("quals", node.Filter)
+		}
+	} else if node.Filter != "" {
+		tree.AddMetaNode("filter", node.Filter)
+	}
+	if node.IndexCond != "" {
+		tree.AddMetaNode("index cond", node.IndexCond)
+	}
+	if node.HashCond != "" {
+		tree.AddMetaNode("hash cond", node.HashCond)
+	}
+	if node.JoinFilter != "" {
+		tree.AddMetaNode("join filter", node.JoinFilter)
+	}
+
+	for _, child := range node.Plans {
+		addPlanNodeDetails(tree.AddBranch(child.label()), child, connections)
+	}
+}
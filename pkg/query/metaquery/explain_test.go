@@ -0,0 +1,58 @@
+package metaquery
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+func TestGetQueryFromMetaquery(t *testing.T) {
+	cases := map[string]string{
+		`.explain select * from aws_s3_bucket`:             `select * from aws_s3_bucket`,
+		`.analyze select * from aws_s3_bucket;`:            `select * from aws_s3_bucket`,
+		".explain\nselect *\nfrom aws_s3_bucket\nlimit 1;": "select *\nfrom aws_s3_bucket\nlimit 1",
+		`.explain`: ``,
+	}
+
+	for input, expected := range cases {
+		if actual := getQueryFromMetaquery(input); actual != expected {
+			t.Errorf("%q != %q", actual, expected)
+		}
+	}
+}
+
+func TestParseExplainResult(t *testing.T) {
+	plan := []interface{}{
+		map[string]interface{}{
+			"Plan": map[string]interface{}{
+				"Node Type": "Limit",
+				"Plan Rows": float64(1),
+				"Plans": []interface{}{
+					map[string]interface{}{
+						"Node Type":     "Foreign Scan",
+						"Relation Name": "aws_s3_bucket",
+						"Schema":        "aws",
+						"Plan Rows":     float64(200),
+						"Filter":        "(aws_s3_bucket.name = 'foo'::text)",
+					},
+				},
+			},
+		},
+	}
+	res := &queryresult.SyncQueryResult{Rows: []interface{}{&queryresult.RowResult{Data: []interface{}{plan}}}}
+
+	plans, err := parseExplainResult(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 1 || len(plans[0].Plan.Plans) != 1 {
+		t.Fatalf("unexpected plan structure: %v", plans)
+	}
+	scan := plans[0].Plan.Plans[0]
+	if scan.NodeType != foreignScanNodeType || scan.Schema != "aws" || scan.PlanRows != 200 {
+		t.Errorf("unexpected foreign scan node: %+v", scan)
+	}
+	if scan.Filter != "(aws_s3_bucket.name = 'foo'::text)" {
+		t.Errorf("unexpected filter: %s", scan.Filter)
+	}
+}
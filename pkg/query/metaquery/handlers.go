@@ -18,6 +18,8 @@ import (
 	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/db/db_common"
 	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/query/queryhistory"
+	"github.com/turbot/steampipe/pkg/query/querysnippets"
 	"github.com/turbot/steampipe/pkg/schema"
 	"github.com/turbot/steampipe/pkg/steampipeconfig"
 	"github.com/turbot/steampipe/sperr"
@@ -33,6 +35,10 @@ type HandlerInput struct {
 	Prompt      *prompt.Prompt
 	ClosePrompt func()
 	Query       string
+	History     *queryhistory.QueryHistory
+	Snippets    *querysnippets.Snippets
+	// SetPromptText sets the text the prompt is populated with when it restarts
+	SetPromptText func(text string)
 }
 type PromptControl interface {
 	Clear()
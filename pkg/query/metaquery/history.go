@@ -0,0 +1,64 @@
+package metaquery
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/query/queryhistory"
+)
+
+// showHistory lists the history entries which match the search term (if given)
+// if the argument is an entry number, that entry is loaded into the prompt, so it can be edited or re-run
+func showHistory(_ context.Context, input *HandlerInput) error {
+	if input.History == nil {
+		return fmt.Errorf("query history is not available")
+	}
+	entries := input.History.Entries()
+	term := getQueryFromMetaquery(input.Query)
+
+	if number, err := strconv.Atoi(term); err == nil {
+		if number < 1 || number > len(entries) {
+			return fmt.Errorf("history entry %d does not exist", number)
+		}
+		input.SetPromptText(entries[number-1].Query)
+		return nil
+	}
+
+	var rows [][]string
+	for i, entry := range entries {
+		// do not show the history metaqueries themselves
+		if strings.HasPrefix(entry.Query, constants.CmdHistory) {
+			continue
+		}
+		if term != "" && !entry.Matches(term) {
+			continue
+		}
+		rows = append(rows, entry.DisplayRow(i+1))
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No matching history entries.")
+		return nil
+	}
+
+	// only show the most recent entries
+	matchCount := len(rows)
+	if matchCount > constants.HistoryDisplaySize {
+		rows = rows[matchCount-constants.HistoryDisplaySize:]
+	}
+
+	display.ShowWrappedTable(queryhistory.DisplayHeaders, rows, &display.ShowWrappedTableOptions{
+		AutoMerge:        false,
+		HideEmptyColumns: true,
+		Truncate:         true,
+	})
+	if matchCount > len(rows) {
+		fmt.Printf("Showing the %d most recent of %d matching entries.\n", len(rows), matchCount)
+	}
+	fmt.Printf("\nTo load an entry into the prompt, run %s\n\n", constants.Bold(".history {number}"))
+	return nil
+}
@@ -0,0 +1,63 @@
+package metaquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/display"
+)
+
+// saveSnippet saves the most recently executed query as a snippet with the given name
+func saveSnippet(_ context.Context, input *HandlerInput) error {
+	if input.Snippets == nil || input.History == nil {
+		return fmt.Errorf("snippets are not available")
+	}
+	name := input.args()[0]
+
+	query := lastExecutedQuery(input)
+	if query == "" {
+		return fmt.Errorf("there is no query to save")
+	}
+	// if the last query was itself a snippet, save the snippet query
+	if snippetQuery, ok := input.Snippets.ResolveQuery(query); ok {
+		query = snippetQuery
+	}
+	if err := input.Snippets.Save(name, query); err != nil {
+		return err
+	}
+	fmt.Printf("Saved snippet '%s'. To run it, enter %s\n", name, constants.Bold(name))
+	return nil
+}
+
+// lastExecutedQuery returns the most recent query in the history which is not a metaquery
+func lastExecutedQuery(input *HandlerInput) string {
+	entries := input.History.Entries()
+	for i := len(entries) - 1; i >= 0; i-- {
+		if query := entries[i].Query; !IsMetaQuery(query) {
+			return query
+		}
+	}
+	return ""
+}
+
+// listSnippets lists the saved snippets
+func listSnippets(_ context.Context, input *HandlerInput) error {
+	if input.Snippets == nil {
+		return fmt.Errorf("snippets are not available")
+	}
+	snippets := input.Snippets.List()
+	if len(snippets) == 0 {
+		fmt.Printf("No snippets saved. To save the last query as a snippet, run %s\n", constants.Bold(".save {name}"))
+		return nil
+	}
+
+	header := []string{"Name", "Query"}
+	var rows [][]string
+	for _, snippet := range snippets {
+		rows = append(rows, []string{snippet.Name, strings.TrimSpace(snippet.Query)})
+	}
+	display.ShowWrappedTable(header, rows, &display.ShowWrappedTableOptions{AutoMerge: false})
+	return nil
+}
@@ -0,0 +1,230 @@
+package querydiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+// DiffColumnName is the name of the column added to the diff result to indicate the status of each row
+const DiffColumnName = "diff"
+
+// diff statuses
+const (
+	StatusAdded      = "added"
+	StatusRemoved    = "removed"
+	StatusChangedOld = "changed (old)"
+	StatusChangedNew = "changed (new)"
+)
+
+// PreviousResult is a query result loaded from the JSON output of a previous query run,
+// indexed by the key columns so that rows of the current result can be matched against it
+type PreviousResult struct {
+	keyColumns []string
+	rows       []map[string]any
+	// map of row key to the indexes of the rows with that key
+	index map[string][]int
+	// the rows which have been matched to a row of the current result
+	matched []bool
+}
+
+// LoadPreviousResult loads the JSON output of a previous query run
+// if no key columns are specified, rows are matched using all of their columns
+// (in which case rows will only ever be reported as added or removed)
+func LoadPreviousResult(path string, keyColumns []string) (*PreviousResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %s", path, err.Error())
+	}
+
+	var rows []map[string]any
+	if err := unmarshalJSON(data, &rows); err != nil {
+		return nil, fmt.Errorf("failed to parse '%s' - it must contain query output in json format: %s", path, err.Error())
+	}
+
+	return &PreviousResult{
+		keyColumns: keyColumns,
+		rows:       rows,
+		matched:    make([]bool, len(rows)),
+	}, nil
+}
+
+// Diff compares the rows of the current result to the previous result, returning a result
+// which streams the rows which have been added, removed or changed
+// added and changed rows are streamed as the current result is read
+// the removed rows (i.e. the previous rows which were not matched) are streamed once the current result is complete
+// NOTE: the current result is fully read, even if the returned result stops being read after an error row
+func (p *PreviousResult) Diff(current *queryresult.Result) *queryresult.Result {
+	cols := append([]*queryresult.ColumnDef{{Name: DiffColumnName, DataType: "TEXT"}}, current.Cols...)
+	res := queryresult.NewResult(cols)
+
+	go func() {
+		defer func() {
+			// drain any rows which were not read because of an error
+			for range *current.RowChan {
+			}
+			// the timing result (if any) is sent before the row channel is closed
+			select {
+			case timingResult := <-current.TimingResult:
+				res.TimingResult <- timingResult
+			default:
+			}
+			res.Close()
+		}()
+
+		keyColumns, err := p.resolveKeyColumns(current.Cols)
+		if err == nil {
+			err = p.buildIndex(keyColumns)
+		}
+		if err != nil {
+			res.StreamError(err)
+			return
+		}
+
+		for row := range *current.RowChan {
+			if row.Error != nil {
+				res.StreamError(row.Error)
+				return
+			}
+			currentRow, err := normaliseRow(row.Data, current.Cols)
+			if err != nil {
+				res.StreamError(err)
+				return
+			}
+			key, err := rowKey(currentRow, keyColumns)
+			if err != nil {
+				res.StreamError(err)
+				return
+			}
+
+			previousIdx, found := p.match(key)
+			switch {
+			case !found:
+				res.StreamRow(diffRow(StatusAdded, currentRow, current.Cols))
+			case !rowsEqual(p.rows[previousIdx], currentRow, current.Cols):
+				res.StreamRow(diffRow(StatusChangedOld, p.rows[previousIdx], current.Cols))
+				res.StreamRow(diffRow(StatusChangedNew, currentRow, current.Cols))
+			}
+		}
+
+		// now stream the removed rows
+		for i, previousRow := range p.rows {
+			if !p.matched[i] {
+				res.StreamRow(diffRow(StatusRemoved, previousRow, current.Cols))
+			}
+		}
+	}()
+
+	return res
+}
+
+// resolveKeyColumns verifies the key columns exist in the current result
+// if no key columns were specified, all columns are used
+func (p *PreviousResult) resolveKeyColumns(cols []*queryresult.ColumnDef) ([]string, error) {
+	colNames := display.ColumnNames(cols)
+	if len(p.keyColumns) == 0 {
+		return colNames, nil
+	}
+	for _, keyColumn := range p.keyColumns {
+		found := false
+		for _, c := range colNames {
+			if c == keyColumn {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("diff key column '%s' is not returned by the query", keyColumn)
+		}
+	}
+	return p.keyColumns, nil
+}
+
+func (p *PreviousResult) buildIndex(keyColumns []string) error {
+	p.index = make(map[string][]int, len(p.rows))
+	for i, row := range p.rows {
+		key, err := rowKey(row, keyColumns)
+		if err != nil {
+			return err
+		}
+		p.index[key] = append(p.index[key], i)
+	}
+	return nil
+}
+
+// match returns the index of the first unmatched previous row with the given key
+func (p *PreviousResult) match(key string) (int, bool) {
+	for _, idx := range p.index[key] {
+		if !p.matched[idx] {
+			p.matched[idx] = true
+			return idx, true
+		}
+	}
+	return 0, false
+}
+
+// normaliseRow converts a row of the current result into the same form as a row read from the previous JSON output,
+// i.e. the values are converted as they are for json output, then round-tripped through JSON
+func normaliseRow(data []any, cols []*queryresult.ColumnDef) (map[string]any, error) {
+	row := make(map[string]any, len(cols))
+	for idx, col := range cols {
+		val, err := display.ParseJSONOutputColumnValue(data[idx], col)
+		if err != nil {
+			return nil, err
+		}
+		row[col.Name] = val
+	}
+
+	jsonBytes, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+	var res map[string]any
+	if err := unmarshalJSON(jsonBytes, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// unmarshalJSON unmarshals the data, preserving numbers as json.Number
+// (to avoid losing precision of large integers)
+func unmarshalJSON(data []byte, target any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
+
+func rowKey(row map[string]any, keyColumns []string) (string, error) {
+	keyValues := make([]any, len(keyColumns))
+	for i, c := range keyColumns {
+		keyValues[i] = row[c]
+	}
+	key, err := json.Marshal(keyValues)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
+func rowsEqual(previous, current map[string]any, cols []*queryresult.ColumnDef) bool {
+	for _, col := range cols {
+		if !reflect.DeepEqual(previous[col.Name], current[col.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func diffRow(status string, row map[string]any, cols []*queryresult.ColumnDef) []any {
+	res := make([]any, len(cols)+1)
+	res[0] = status
+	for i, col := range cols {
+		res[i+1] = row[col.Name]
+	}
+	return res
+}
@@ -0,0 +1,76 @@
+package querydiff
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+func TestDiff(t *testing.T) {
+	previousPath := filepath.Join(t.TempDir(), "previous.json")
+	previous := `[
+ {"id": 1, "name": "a", "region": "us-east-1"},
+ {"id": 2, "name": "b", "region": "us-east-1"},
+ {"id": 3, "name": "c", "region": "us-east-1"}
+]`
+	if err := os.WriteFile(previousPath, []byte(previous), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cols := []*queryresult.ColumnDef{
+		{Name: "id", DataType: "INT8"},
+		{Name: "name", DataType: "TEXT"},
+		{Name: "region", DataType: "TEXT"},
+	}
+	current := queryresult.NewResult(cols)
+	go func() {
+		// 1 is unchanged, 2 has changed, 3 has been removed and 4 is new
+		current.StreamRow([]any{int64(1), "a", "us-east-1"})
+		current.StreamRow([]any{int64(2), "b", "eu-west-1"})
+		current.StreamRow([]any{int64(4), "d", "us-east-1"})
+		current.Close()
+	}()
+
+	p, err := LoadPreviousResult(previousPath, []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := p.Diff(current)
+
+	var actual [][]any
+	for row := range *diff.RowChan {
+		if row.Error != nil {
+			t.Fatal(row.Error)
+		}
+		actual = append(actual, row.Data)
+	}
+
+	expected := [][]any{
+		{StatusChangedOld, json.Number("2"), "b", "us-east-1"},
+		{StatusChangedNew, json.Number("2"), "b", "eu-west-1"},
+		{StatusAdded, json.Number("4"), "d", "us-east-1"},
+		{StatusRemoved, json.Number("3"), "c", "us-east-1"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestDiffInvalidKeyColumn(t *testing.T) {
+	p := &PreviousResult{keyColumns: []string{"missing"}}
+
+	current := queryresult.NewResult([]*queryresult.ColumnDef{{Name: "id", DataType: "INT8"}})
+	go func() {
+		current.StreamRow([]any{int64(1)})
+		current.Close()
+	}()
+
+	row := <-*p.Diff(current).RowChan
+	if row == nil || row.Error == nil {
+		t.Errorf("expected an error for a missing key column")
+	}
+}
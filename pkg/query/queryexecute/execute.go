@@ -3,6 +3,7 @@ package queryexecute
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/spf13/viper"
@@ -14,6 +15,8 @@ import (
 	"github.com/turbot/steampipe/pkg/error_helpers"
 	"github.com/turbot/steampipe/pkg/interactive"
 	"github.com/turbot/steampipe/pkg/query"
+	"github.com/turbot/steampipe/pkg/query/querydiff"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
 	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/pkg/utils"
 )
@@ -69,12 +72,26 @@ func executeQueries(ctx context.Context, initData *query.InitData) int {
 	// build ordered list of queries
 	// (ordered for testing repeatability)
 	var queryNames = utils.SortedMapKeys(initData.Queries)
-	var err error
+
+	// if parallel execution is enabled, start all queries now - the results are buffered and displayed in order
+	var parallelResults []*parallelQueryResult
+	if parallel := viper.GetInt64(constants.ArgParallel); parallel > 1 && len(queryNames) > 1 {
+		parallelResults = startParallelQueries(ctx, initData, queryNames, parallel)
+	}
 
 	for i, name := range queryNames {
-		q := initData.Queries[name]
+		var err error
+		var rowErrors int
 		// if executeQuery fails it returns err, else it returns the number of rows that returned errors while execution
-		if err, failures = executeQuery(ctx, initData.Client, q); err != nil {
+		exportName := queryExportName(i, len(queryNames))
+		if parallelResults != nil {
+			err, rowErrors = displayParallelQueryResult(ctx, initData, exportName, parallelResults[i])
+		} else {
+			err, rowErrors = executeQuery(ctx, initData, name, exportName, initData.Queries[name])
+		}
+		// aggregate the failures of all queries
+		failures += rowErrors
+		if err != nil {
 			failures++
 			error_helpers.ShowWarning(fmt.Sprintf("executeQueries: query %d of %d failed: %v", i+1, len(queryNames), error_helpers.DecodePgError(err)))
 			// if timing flag is enabled, show the time taken for the query to fail
@@ -92,12 +109,21 @@ func executeQueries(ctx context.Context, initData *query.InitData) int {
 	return failures
 }
 
-func executeQuery(ctx context.Context, client db_common.Client, resolvedQuery *modconfig.ResolvedQuery) (error, int) {
+// queryExportName returns the execution name used to generate the default export file names for a query
+// if more than one query is executed, the query index is included so each result is exported to a separate file
+func queryExportName(queryIdx, queryCount int) string {
+	if queryCount == 1 {
+		return "query"
+	}
+	return fmt.Sprintf("query_%d", queryIdx+1)
+}
+
+func executeQuery(ctx context.Context, initData *query.InitData, queryName, exportName string, resolvedQuery *modconfig.ResolvedQuery) (error, int) {
 	utils.LogTime("query.execute.executeQuery start")
 	defer utils.LogTime("query.execute.executeQuery end")
 
 	// the db executor sends result data over resultsStreamer
-	resultsStreamer, err := db_common.ExecuteQuery(ctx, client, resolvedQuery.ExecuteSQL, resolvedQuery.Args...)
+	resultsStreamer, err := db_common.ExecuteQuery(ctx, initData.Client, resolvedQuery.ExecuteSQL, resolvedQuery.Args...)
 	if err != nil {
 		return err, 0
 	}
@@ -105,14 +131,100 @@ func executeQuery(ctx context.Context, client db_common.Client, resolvedQuery *m
 	rowErrors := 0 // get the number of rows that returned an error
 	// print the data as it comes
 	for r := range resultsStreamer.Results {
-		rowErrors = display.ShowOutput(ctx, r)
+		r.QueryName = queryName
+		rowErrors = displayResult(ctx, initData, exportName, r)
 		// signal to the resultStreamer that we are done with this result
 		resultsStreamer.AllResultsRead()
 	}
 	return nil, rowErrors
 }
 
-// if we are displaying csv with no header, do not include lines between the query results
+// displayResult displays the result, streaming it to any requested exporters as it is displayed
+// it returns the number of rows that returned errors (a failed export is counted as a row error)
+func displayResult(ctx context.Context, initData *query.InitData, exportName string, result *queryresult.Result) int {
+	result, waitForExport := exportResult(ctx, initData, exportName, result)
+	rowErrors := 0
+	// if we are comparing with a previous result, display the differences rather than the result itself
+	// (any exports still contain the full result)
+	if diffPath := viper.GetString(constants.ArgDiffAgainst); diffPath != "" {
+		rowErrors += displayDiff(ctx, diffPath, result)
+	} else {
+		rowErrors += display.ShowOutput(ctx, result)
+	}
+	if err := waitForExport(); err != nil {
+		error_helpers.ShowError(ctx, err)
+		rowErrors++
+	}
+	return rowErrors
+}
+
+// displayDiff compares the result to the previous result (read from diffPath) and displays the differences
+func displayDiff(ctx context.Context, diffPath string, result *queryresult.Result) int {
+	previous, err := querydiff.LoadPreviousResult(diffPath, viper.GetStringSlice(constants.ArgDiffKey))
+	if err != nil {
+		error_helpers.ShowError(ctx, err)
+		// drain the result so any exports are not blocked
+		for range *result.RowChan {
+		}
+		return 1
+	}
+	diffResult := previous.Diff(result)
+	rowErrors := display.ShowOutput(ctx, diffResult)
+	// drain any rows which were not displayed (e.g. for output 'none')
+	for range *diffResult.RowChan {
+	}
+	return rowErrors
+}
+
+// exportResult starts exporting the result to all export targets specified by the export arg
+// exportName is used to generate the file name for targets which only specify the export format
+// it returns a result to use for display, and a function which waits for the exports to complete
+func exportResult(ctx context.Context, initData *query.InitData, exportName string, result *queryresult.Result) (*queryresult.Result, func() error) {
+	exportArgs := viper.GetStringSlice(constants.ArgExport)
+	if len(exportArgs) == 0 {
+		return result, func() error { return nil }
+	}
+
+	// tee the result - one for display and one for each export target
+	results := result.Tee(len(exportArgs) + 1)
+
+	var wg sync.WaitGroup
+	exportMsgs := make([][]string, len(exportArgs))
+	exportErrors := make([]error, len(exportArgs))
+	for i, exportArg := range exportArgs {
+		wg.Add(1)
+		go func(i int, exportArg string, exportResult *queryresult.Result) {
+			defer wg.Done()
+			exportMsgs[i], exportErrors[i] = initData.ExportManager.DoExport(ctx, exportName, exportResult, []string{exportArg})
+			// if the export failed before reading all rows, drain the result so the other results are not blocked
+			for range *exportResult.RowChan {
+			}
+		}(i, exportArg, results[i+1])
+	}
+
+	waitForExport := func() error {
+		// drain any rows which were not read by the display (e.g. for output 'none')
+		for range *results[0].RowChan {
+		}
+		wg.Wait()
+		// print the location where the files are exported
+		if viper.GetBool(constants.ArgProgress) {
+			for _, msgs := range exportMsgs {
+				for _, msg := range msgs {
+					fmt.Println(msg)
+				}
+			}
+		}
+		return error_helpers.CombineErrors(exportErrors...)
+	}
+	return results[0], waitForExport
+}
+
+// if we are displaying csv with no header, jsonl or a binary format, do not include lines between the query results
 func showBlankLineBetweenResults() bool {
-	return !(viper.GetString(constants.ArgOutput) == "csv" && !viper.GetBool(constants.ArgHeader))
+	output := viper.GetString(constants.ArgOutput)
+	if output == constants.OutputFormatJSONL || output == constants.OutputFormatParquet || output == constants.OutputFormatArrow {
+		return false
+	}
+	return !(output == "csv" && !viper.GetBool(constants.ArgHeader))
 }
@@ -0,0 +1,72 @@
+package queryexecute
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe/pkg/query"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/pkg/utils"
+	"golang.org/x/sync/semaphore"
+)
+
+// parallelQueryResult holds the buffered result of a query which was executed in parallel
+type parallelQueryResult struct {
+	queryName string
+	result    *queryresult.SyncQueryResult
+	err       error
+	// closed when the query execution is complete
+	done chan struct{}
+}
+
+// startParallelQueries starts executing the given queries, running at most 'parallel' at once
+// it returns a parallelQueryResult for each query, in the same order as queryNames
+func startParallelQueries(ctx context.Context, initData *query.InitData, queryNames []string, parallel int64) []*parallelQueryResult {
+	utils.LogTime("queryexecute.startParallelQueries start")
+	defer utils.LogTime("queryexecute.startParallelQueries end")
+
+	// to limit the number of queries executing at once
+	parallelismLock := semaphore.NewWeighted(parallel)
+
+	results := make([]*parallelQueryResult, len(queryNames))
+	for i, name := range queryNames {
+		results[i] = &parallelQueryResult{queryName: name, done: make(chan struct{})}
+		go executeParallelQuery(ctx, initData, initData.Queries[name], parallelismLock, results[i])
+	}
+	return results
+}
+
+func executeParallelQuery(ctx context.Context, initData *query.InitData, resolvedQuery *modconfig.ResolvedQuery, parallelismLock *semaphore.Weighted, res *parallelQueryResult) {
+	defer close(res.done)
+
+	if err := parallelismLock.Acquire(ctx, 1); err != nil {
+		res.err = err
+		return
+	}
+	defer parallelismLock.Release(1)
+
+	// execute the query, buffering all rows
+	// NOTE: if a row returns an error, ExecuteSync returns that error along with the partial result
+	res.result, res.err = initData.Client.ExecuteSync(ctx, resolvedQuery.ExecuteSQL, resolvedQuery.Args...)
+}
+
+// displayParallelQueryResult waits for the query to complete, then displays (and exports) the buffered result
+func displayParallelQueryResult(ctx context.Context, initData *query.InitData, exportName string, res *parallelQueryResult) (error, int) {
+	<-res.done
+	// if the query failed without returning a result, there is nothing to display
+	if res.result == nil {
+		return res.err, 0
+	}
+
+	// display any rows which were returned
+	result := res.result.AsResult()
+	result.QueryName = res.queryName
+	rowErrors := displayResult(ctx, initData, exportName, result)
+
+	// if an error was returned with a partial result, report it after the rows
+	// (unless it was already reported as an error row when the result was displayed)
+	if res.err != nil && rowErrors == 0 {
+		return res.err, 0
+	}
+	return nil, rowErrors
+}
@@ -0,0 +1,91 @@
+package queryexecute
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/db/db_common"
+	"github.com/turbot/steampipe/pkg/query"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+// parallelTestClient is a client whose queries return the query sql as a single row
+// queries complete in reverse order of their delay, and the query 'fail' returns an error
+type parallelTestClient struct {
+	db_common.Client
+	delays map[string]time.Duration
+}
+
+func (c *parallelTestClient) ExecuteSync(ctx context.Context, sql string, _ ...any) (*queryresult.SyncQueryResult, error) {
+	time.Sleep(c.delays[sql])
+	if sql == "fail" {
+		return nil, fmt.Errorf("query failed")
+	}
+	return &queryresult.SyncQueryResult{
+		Cols: []*queryresult.ColumnDef{{Name: "query", DataType: "TEXT"}},
+		Rows: []interface{}{&queryresult.RowResult{Data: []interface{}{sql}}},
+	}, nil
+}
+
+func TestExecuteQueriesParallel(t *testing.T) {
+	viper.Set(constants.ArgParallel, 4)
+	viper.Set(constants.ArgOutput, constants.OutputFormatCSV)
+	viper.Set(constants.ArgHeader, false)
+	viper.Set(constants.ArgSeparator, ",")
+	defer viper.Reset()
+
+	// the queries are executed in name order - make the first queries the slowest to complete
+	client := &parallelTestClient{delays: map[string]time.Duration{
+		"a":    60 * time.Millisecond,
+		"b":    40 * time.Millisecond,
+		"fail": 20 * time.Millisecond,
+		"z":    0,
+	}}
+	initData := &query.InitData{Queries: make(map[string]*modconfig.ResolvedQuery)}
+	initData.Client = client
+	for name := range client.delays {
+		initData.Queries[name] = &modconfig.ResolvedQuery{ExecuteSQL: name}
+	}
+
+	var failures int
+	output := captureStdout(t, func() {
+		failures = executeQueries(context.Background(), initData)
+	})
+
+	if failures != 1 {
+		t.Errorf("expected 1 failure, got %d", failures)
+	}
+	// the results of the other queries are displayed in input order
+	if expected := "a\nb\nz\n"; output != expected {
+		t.Errorf("expected output %q, got %q", expected, output)
+	}
+}
+
+func captureStdout(t *testing.T, f func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	outChan := make(chan string)
+	go func() {
+		var sb strings.Builder
+		_, _ = io.Copy(&sb, r)
+		outChan <- sb.String()
+	}()
+
+	f()
+	w.Close()
+	return <-outChan
+}
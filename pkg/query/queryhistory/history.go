@@ -6,55 +6,60 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/filepaths"
 )
 
 // QueryHistory :: struct for working with history in the interactive mode
 type QueryHistory struct {
-	history []string
+	history []*HistoryEntry
 }
 
 // New creates a new QueryHistory object
 func New() (*QueryHistory, error) {
-	history := &QueryHistory{history: []string{}}
+	history := &QueryHistory{history: []*HistoryEntry{}}
 	err := history.load()
 	if err != nil {
 		return nil, err
 	}
+	// remove any entries which have expired since the history was last persisted
+	history.enforceRetention()
 	return history, nil
 }
 
-// Push adds a string to the history queue trimming to maxHistorySize if necessary
-func (q *QueryHistory) Push(query string) {
+// Push adds a query to the history queue, enforcing the configured retention
+// it returns the history entry, so that the caller can record the result of the execution
+func (q *QueryHistory) Push(query string) *HistoryEntry {
 	if len(strings.TrimSpace(query)) == 0 {
 		// do not store a blank query
-		return
+		return nil
 	}
 
-	// do a strict compare to see if we have this same exact query as the most recent history item
-	if lastElement := q.Peek(); lastElement != nil && (*lastElement) == query {
-		return
-	}
+	entry := newHistoryEntry(query)
 
-	// limit the history length to HistorySize
-	historyLength := len(q.history)
-	if historyLength >= constants.HistorySize {
-		q.history = q.history[historyLength-constants.HistorySize+1:]
+	// do a strict compare to see if we have this same exact query as the most recent history item
+	// if so, replace it, so the history reflects the most recent execution
+	if lastElement := q.Peek(); lastElement != nil && lastElement.Query == query {
+		q.history[len(q.history)-1] = entry
+		return entry
 	}
 
 	// append the new entry
-	q.history = append(q.history, query)
+	q.history = append(q.history, entry)
+	q.enforceRetention()
+	return entry
 }
 
 // Peek returns the last element of the history stack.
 // returns nil if there is no history
-func (q *QueryHistory) Peek() *string {
+func (q *QueryHistory) Peek() *HistoryEntry {
 	if len(q.history) == 0 {
 		return nil
 	}
-	return &q.history[len(q.history)-1]
+	return q.history[len(q.history)-1]
 }
 
 // Persist writes the history to the filesystem
@@ -64,8 +69,7 @@ func (q *QueryHistory) Persist() error {
 	defer func() {
 		file.Close()
 	}()
-	path := filepath.Join(filepaths.EnsureInternalDir(), constants.HistoryFile)
-	file, err = os.Create(path)
+	file, err = os.Create(historyFilePath())
 	if err != nil {
 		return err
 	}
@@ -78,15 +82,47 @@ func (q *QueryHistory) Persist() error {
 	return jsonEncoder.Encode(q.history)
 }
 
-// Get returns the full history
+// Get returns the queries of the full history
 func (q *QueryHistory) Get() []string {
+	queries := make([]string, len(q.history))
+	for i, entry := range q.history {
+		queries[i] = entry.Query
+	}
+	return queries
+}
+
+// Entries returns the full history, oldest first
+func (q *QueryHistory) Entries() []*HistoryEntry {
 	return q.history
 }
 
+// enforceRetention removes entries older than the configured max age (if set)
+// and trims the history to the configured max size
+func (q *QueryHistory) enforceRetention() {
+	if maxAgeDays := viper.GetInt(constants.ArgHistoryMaxAge); maxAgeDays > 0 {
+		cutoff := time.Now().Add(-time.Duration(maxAgeDays) * 24 * time.Hour)
+		var retained []*HistoryEntry
+		for _, entry := range q.history {
+			// entries without a timestamp were written by an earlier version of steampipe - keep them
+			if entry.Timestamp.IsZero() || entry.Timestamp.After(cutoff) {
+				retained = append(retained, entry)
+			}
+		}
+		q.history = retained
+	}
+
+	maxSize := viper.GetInt(constants.ArgHistorySize)
+	if maxSize <= 0 {
+		maxSize = constants.HistorySize
+	}
+	if historyLength := len(q.history); historyLength > maxSize {
+		q.history = q.history[historyLength-maxSize:]
+	}
+}
+
 // loads up the history from the file where it is persisted
 func (q *QueryHistory) load() error {
-	path := filepath.Join(filepaths.EnsureInternalDir(), constants.HistoryFile)
-	file, err := os.Open(path)
+	file, err := os.Open(historyFilePath())
 	if err != nil {
 		// ignore not exists errors
 		if os.IsNotExist(err) {
@@ -105,3 +141,7 @@ func (q *QueryHistory) load() error {
 	}
 	return err
 }
+
+func historyFilePath() string {
+	return filepath.Join(filepaths.EnsureInternalDir(), constants.HistoryFile)
+}
@@ -0,0 +1,90 @@
+package queryhistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+// HistoryEntry is a single query history item, along with the details of its execution
+type HistoryEntry struct {
+	Query     string    `json:"query"`
+	Timestamp time.Time `json:"timestamp"`
+	// the workspace (mod location) the query was executed in
+	Workspace string `json:"workspace,omitempty"`
+	// execution details - these are nil if the query was not executed (or is a metaquery)
+	DurationMs *int64 `json:"duration_ms,omitempty"`
+	Rows       *int64 `json:"rows,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func newHistoryEntry(query string) *HistoryEntry {
+	return &HistoryEntry{
+		Query:     query,
+		Timestamp: time.Now(),
+		Workspace: viper.GetString(constants.ArgModLocation),
+	}
+}
+
+// SetResult records the outcome of executing the query
+func (e *HistoryEntry) SetResult(duration time.Duration, rows int64, err error) {
+	if e == nil {
+		return
+	}
+	durationMs := duration.Milliseconds()
+	e.DurationMs = &durationMs
+	e.Rows = &rows
+	if err != nil {
+		e.Error = err.Error()
+	}
+}
+
+// Matches returns whether the query, workspace or error of the entry contain the search term (case insensitive)
+func (e *HistoryEntry) Matches(term string) bool {
+	term = strings.ToLower(term)
+	return strings.Contains(strings.ToLower(e.Query), term) ||
+		strings.Contains(strings.ToLower(e.Workspace), term) ||
+		strings.Contains(strings.ToLower(e.Error), term)
+}
+
+// DisplayHeaders are the column headers used when displaying history entries
+var DisplayHeaders = []string{"#", "Timestamp", "Workspace", "Duration", "Rows", "Error", "Query"}
+
+// DisplayRow returns the column values used when displaying the entry
+// number is the (1 based) position of the entry in the history
+func (e *HistoryEntry) DisplayRow(number int) []string {
+	var timestamp, duration, rows string
+	if !e.Timestamp.IsZero() {
+		timestamp = e.Timestamp.Format(time.RFC3339)
+	}
+	if e.DurationMs != nil {
+		duration = fmt.Sprintf("%dms", *e.DurationMs)
+	}
+	if e.Rows != nil {
+		rows = fmt.Sprintf("%d", *e.Rows)
+	}
+	return []string{fmt.Sprintf("%d", number), timestamp, e.Workspace, duration, rows, e.Error, e.Query}
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+// earlier versions of steampipe persisted the history as an array of strings - support reading these
+func (e *HistoryEntry) UnmarshalJSON(data []byte) error {
+	var query string
+	if err := json.Unmarshal(data, &query); err == nil {
+		*e = HistoryEntry{Query: query}
+		return nil
+	}
+
+	// use an alias type to avoid recursing into this function
+	type entryAlias HistoryEntry
+	var alias entryAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*e = HistoryEntry(alias)
+	return nil
+}
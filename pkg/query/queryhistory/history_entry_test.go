@@ -0,0 +1,41 @@
+package queryhistory
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnmarshalHistory(t *testing.T) {
+	// history persisted by earlier versions is an array of strings
+	data := `["select 1", {"query":"select 2","timestamp":"2023-01-02T03:04:05Z","rows":1,"duration_ms":12,"error":"boom"}]`
+
+	var history []*HistoryEntry
+	if err := json.Unmarshal([]byte(data), &history); err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(history))
+	}
+	if history[0].Query != "select 1" || !history[0].Timestamp.IsZero() || history[0].Rows != nil {
+		t.Errorf("unexpected legacy entry: %+v", history[0])
+	}
+	if history[1].Query != "select 2" || *history[1].Rows != 1 || *history[1].DurationMs != 12 || history[1].Error != "boom" {
+		t.Errorf("unexpected entry: %+v", history[1])
+	}
+}
+
+func TestHistoryEntryMatches(t *testing.T) {
+	entry := &HistoryEntry{Query: "select * from aws_s3_bucket", Error: "access denied"}
+
+	cases := map[string]bool{
+		"AWS_S3":   true,
+		"denied":   true,
+		"gcp":      false,
+		"bucket ;": false,
+	}
+	for term, expected := range cases {
+		if actual := entry.Matches(term); actual != expected {
+			t.Errorf("Matches(%q) = %v, expected %v", term, actual, expected)
+		}
+	}
+}
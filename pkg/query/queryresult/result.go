@@ -22,6 +22,9 @@ type Result struct {
 	RowChan      *chan *RowResult
 	Cols         []*ColumnDef
 	TimingResult chan *TimingResult
+	// the name of the query which produced this result (set when executing batch queries)
+	// this is either the name of a named query or the query SQL
+	QueryName string
 }
 
 func NewResult(cols []*ColumnDef) *Result {
@@ -54,3 +57,48 @@ type SyncQueryResult struct {
 	Cols         []*ColumnDef
 	TimingResult *TimingResult
 }
+
+// Tee returns count results, each of which streams a copy of the rows (and timing) of this result
+// NOTE: rows are streamed to all of the returned results in lockstep, so each of them MUST be fully read
+func (r *Result) Tee(count int) []*Result {
+	results := make([]*Result, count)
+	for i := range results {
+		results[i] = NewResult(r.Cols)
+		results[i].QueryName = r.QueryName
+	}
+
+	go func() {
+		for row := range *r.RowChan {
+			for _, res := range results {
+				*res.RowChan <- row
+			}
+		}
+		// the timing result (if any) is sent before the row channel is closed
+		select {
+		case timingResult := <-r.TimingResult:
+			for _, res := range results {
+				res.TimingResult <- timingResult
+			}
+		default:
+		}
+		for _, res := range results {
+			res.Close()
+		}
+	}()
+
+	return results
+}
+
+// AsResult returns a Result which streams the rows (and timing) of the SyncQueryResult
+func (r *SyncQueryResult) AsResult() *Result {
+	res := NewResult(r.Cols)
+	go func() {
+		for _, row := range r.Rows {
+			*res.RowChan <- row.(*RowResult)
+		}
+		// always send the timing result (even if nil) so a reader waiting for timing does not block
+		res.TimingResult <- r.TimingResult
+		res.Close()
+	}()
+	return res
+}
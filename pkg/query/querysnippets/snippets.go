@@ -0,0 +1,133 @@
+package querysnippets
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/filepaths"
+)
+
+// snippet names must be valid identifiers, so they cannot be confused with sql or with
+// (qualified) named queries
+var snippetNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// the keywords which may start a sql statement - these cannot be used as snippet names,
+// as a snippet with one of these names would replace the sql statement
+var sqlStatementKeywords = map[string]struct{}{
+	"abort": {}, "alter": {}, "analyse": {}, "analyze": {}, "begin": {}, "call": {}, "checkpoint": {}, "close": {},
+	"cluster": {}, "comment": {}, "commit": {}, "copy": {}, "create": {}, "deallocate": {}, "declare": {}, "delete": {},
+	"discard": {}, "do": {}, "drop": {}, "end": {}, "execute": {}, "explain": {}, "fetch": {}, "grant": {},
+	"import": {}, "insert": {}, "listen": {}, "load": {}, "lock": {}, "merge": {}, "move": {}, "notify": {},
+	"prepare": {}, "reassign": {}, "refresh": {}, "reindex": {}, "release": {}, "reset": {}, "revoke": {}, "rollback": {},
+	"savepoint": {}, "security": {}, "select": {}, "set": {}, "show": {}, "start": {}, "table": {}, "truncate": {},
+	"unlisten": {}, "update": {}, "vacuum": {}, "values": {}, "with": {},
+}
+
+// Snippet is a saved query which can be executed by name
+type Snippet struct {
+	Name    string    `json:"name"`
+	Query   string    `json:"query"`
+	Created time.Time `json:"created"`
+}
+
+// Snippets is the set of query snippets saved by the user
+// snippets are persisted in the steampipe internal directory so are available in any workspace
+type Snippets struct {
+	snippets map[string]*Snippet
+	path     string
+}
+
+// Load loads the saved snippets
+func Load() (*Snippets, error) {
+	return loadFromPath(filepath.Join(filepaths.EnsureInternalDir(), constants.SnippetsFile))
+}
+
+func loadFromPath(path string) (*Snippets, error) {
+	s := &Snippets{
+		snippets: make(map[string]*Snippet),
+		path:     path,
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		// ignore not exists errors
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	if len(data) == 0 {
+		return s, nil
+	}
+	var snippets []*Snippet
+	if err := json.Unmarshal(data, &snippets); err != nil {
+		return nil, fmt.Errorf("failed to load snippets from '%s': %s", path, err.Error())
+	}
+	for _, snippet := range snippets {
+		s.snippets[snippet.Name] = snippet
+	}
+	return s, nil
+}
+
+// Save adds (or replaces) a snippet and persists the snippets
+func (s *Snippets) Save(name, query string) error {
+	if !snippetNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid snippet name '%s' - names may only contain letters, digits, '_' and '-', and must start with a letter or '_'", name)
+	}
+	if _, isKeyword := sqlStatementKeywords[strings.ToLower(name)]; isKeyword {
+		return fmt.Errorf("invalid snippet name '%s' - names cannot be sql keywords", name)
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return fmt.Errorf("cannot save an empty query")
+	}
+	s.snippets[name] = &Snippet{
+		Name:    name,
+		Query:   query,
+		Created: time.Now(),
+	}
+	return s.persist()
+}
+
+// Get returns the snippet with the given name
+func (s *Snippets) Get(name string) (*Snippet, bool) {
+	snippet, ok := s.snippets[name]
+	return snippet, ok
+}
+
+// ResolveQuery returns the snippet query if the given query is the name of a snippet
+// (optionally followed by a semicolon)
+func (s *Snippets) ResolveQuery(query string) (string, bool) {
+	name := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(query), ";"))
+	snippet, ok := s.Get(name)
+	if !ok {
+		return "", false
+	}
+	return snippet.Query, true
+}
+
+// List returns the snippets, sorted by name
+func (s *Snippets) List() []*Snippet {
+	res := make([]*Snippet, 0, len(s.snippets))
+	for _, snippet := range s.snippets {
+		res = append(res, snippet)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+func (s *Snippets) persist() error {
+	data, err := json.MarshalIndent(s.List(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0600)
+}
@@ -0,0 +1,37 @@
+package querysnippets
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndResolveSnippet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snippets.json")
+	snippets, err := loadFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := snippets.Save("buckets", "select * from aws_s3_bucket"); err != nil {
+		t.Fatal(err)
+	}
+	for _, invalidName := range []string{"1buckets", "query.buckets", "my buckets", "select", "COMMIT"} {
+		if err := snippets.Save(invalidName, "select 1"); err == nil {
+			t.Errorf("expected an error saving snippet '%s'", invalidName)
+		}
+	}
+
+	// reload from the file
+	snippets, err = loadFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snippets.List()) != 1 {
+		t.Fatalf("expected 1 snippet, got %d", len(snippets.List()))
+	}
+	if query, ok := snippets.ResolveQuery(" buckets; "); !ok || query != "select * from aws_s3_bucket" {
+		t.Errorf("failed to resolve snippet, got %q", query)
+	}
+	if _, ok := snippets.ResolveQuery("select * from buckets"); ok {
+		t.Errorf("sql should not resolve to a snippet")
+	}
+}
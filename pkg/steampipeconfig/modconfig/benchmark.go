@@ -25,6 +25,12 @@ type Benchmark struct {
 	// used for introspection tables
 	ChildNameStrings []string `cty:"child_name_strings" column:"children,jsonb" json:"-"`
 
+	// the default query timeout in seconds and number of retries for descendant controls
+	Timeout *int `cty:"timeout" column:"timeout,integer" json:"timeout,omitempty"`
+	Retries *int `cty:"retries" column:"retries,integer" json:"retries,omitempty"`
+	// the weight of the benchmark when calculating the score of its parent benchmark (if not set, DefaultWeight is used)
+	Weight *int `cty:"weight" column:"weight,integer" json:"weight,omitempty"`
+
 	// dashboard specific properties
 	Base    *Benchmark `hcl:"base" json:"-"`
 	Width   *int       `cty:"width" hcl:"width" column:"width,text" json:"-"`
@@ -61,7 +67,8 @@ func (b *Benchmark) Equals(other *Benchmark) bool {
 // OnDecoded implements HclResource
 func (b *Benchmark) OnDecoded(block *hcl.Block, _ ResourceMapsProvider) hcl.Diagnostics {
 	b.setBaseProperties()
-	return nil
+	diags := validateTimeoutAndRetries(b.Name(), b.Timeout, b.Retries, &b.DeclRange)
+	return append(diags, validateWeight(b.Name(), b.Weight, &b.DeclRange)...)
 }
 
 func (b *Benchmark) String() string {
@@ -105,6 +112,14 @@ func (b *Benchmark) GetChildControls() []*Control {
 	return res
 }
 
+// GetWeight returns the weight of the benchmark when calculating the score of its parent benchmark
+func (b *Benchmark) GetWeight() int {
+	if b.Weight == nil {
+		return DefaultWeight
+	}
+	return *b.Weight
+}
+
 // GetWidth implements DashboardLeafNode
 func (b *Benchmark) GetWidth() int {
 	if b.Width == nil {
@@ -153,6 +168,18 @@ func (b *Benchmark) Diff(other *Benchmark) *DashboardTreeItemDiffs {
 		}
 	}
 
+	if !utils.SafeIntEqual(b.Timeout, other.Timeout) {
+		res.AddPropertyDiff("Timeout")
+	}
+
+	if !utils.SafeIntEqual(b.Retries, other.Retries) {
+		res.AddPropertyDiff("Retries")
+	}
+
+	if !utils.SafeIntEqual(b.Weight, other.Weight) {
+		res.AddPropertyDiff("Weight")
+	}
+
 	if !utils.SafeStringsEqual(b.Type, other.Type) {
 		res.AddPropertyDiff("Type")
 	}
@@ -218,6 +245,18 @@ func (b *Benchmark) setBaseProperties() {
 		b.Display = b.Base.Display
 	}
 
+	if b.Timeout == nil {
+		b.Timeout = b.Base.Timeout
+	}
+
+	if b.Retries == nil {
+		b.Retries = b.Base.Retries
+	}
+
+	if b.Weight == nil {
+		b.Weight = b.Base.Weight
+	}
+
 	if len(b.children) == 0 {
 		b.children = b.Base.children
 		b.ChildNameStrings = b.Base.ChildNameStrings
@@ -37,6 +37,7 @@ const (
 	BlockTypeConnection       = "connection"
 	BlockTypeOptions          = "options"
 	BlockTypeWorkspaceProfile = "workspace"
+	BlockTypeSchedule         = "schedule"
 
 	ResourceTypeSnapshot = "snapshot"
 	AttributeArgs        = "args"
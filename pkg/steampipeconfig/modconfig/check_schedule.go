@@ -0,0 +1,218 @@
+package modconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// CheckSchedule is a benchmark (or control) run which is executed by the steampipe service on a cron schedule
+// schedules are defined in a workspace profile:
+//
+//	workspace "default" {
+//	  schedule "cis_hourly" {
+//	    benchmark = "aws_compliance.benchmark.cis_v150"
+//	    cron      = "0 * * * *"
+//	  }
+//	}
+type CheckSchedule struct {
+	ScheduleName string `hcl:"name,label"`
+	// the name of the benchmark or control to run
+	Benchmark string `hcl:"benchmark"`
+	// a 5 field cron expression (minute hour day-of-month month day-of-week), or one of the
+	// descriptors @hourly, @daily, @weekly, @monthly or @yearly
+	Cron      string `hcl:"cron"`
+	DeclRange hcl.Range
+
+	cron *cronExpression
+}
+
+func NewCheckSchedule(block *hcl.Block) *CheckSchedule {
+	return &CheckSchedule{
+		ScheduleName: block.Labels[0],
+		DeclRange:    block.TypeRange,
+	}
+}
+
+func (s *CheckSchedule) OnDecoded() hcl.Diagnostics {
+	cron, err := parseCronExpression(s.Cron)
+	if err == nil && cron.next(time.Now()).IsZero() {
+		err = fmt.Errorf("the schedule never runs")
+	}
+	if err != nil {
+		return hcl.Diagnostics{&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("invalid cron expression '%s' for schedule '%s': %s", s.Cron, s.ScheduleName, err.Error()),
+			Subject:  &s.DeclRange,
+		}}
+	}
+	s.cron = cron
+	return nil
+}
+
+// Next returns the first time after t at which the schedule runs
+func (s *CheckSchedule) Next(t time.Time) time.Time {
+	if s.cron == nil {
+		return time.Time{}
+	}
+	return s.cron.next(t)
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// the ranges of the cron fields, in order
+var cronFieldRanges = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// cronExpression is a parsed cron expression - each field is a bitset of the matching values
+type cronExpression struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// if both day of month and day of week are restricted, a day matches if either matches
+	dayOfMonthStar, dayOfWeekStar bool
+}
+
+func parseCronExpression(expr string) (*cronExpression, error) {
+	expr = strings.TrimSpace(expr)
+	if descriptor, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = descriptor
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFieldRanges) {
+		return nil, fmt.Errorf("expected %d fields (minute hour day-of-month month day-of-week), got %d", len(cronFieldRanges), len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		r := cronFieldRanges[i]
+		b, err := parseCronField(field, r.min, r.max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s': %s", r.name, field, err.Error())
+		}
+		bits[i] = b
+	}
+	// day of week 7 is Sunday
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return &cronExpression{
+		minute:         bits[0],
+		hour:           bits[1],
+		dayOfMonth:     bits[2],
+		month:          bits[3],
+		dayOfWeek:      bits[4],
+		dayOfMonthStar: strings.HasPrefix(fields[2], "*"),
+		dayOfWeekStar:  strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses a comma separated list of '*', values and ranges, each with an optional step, e.g. '*/15' or '1-5,10'
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step '%s'", stepPart)
+			}
+		}
+
+		start, end := min, max
+		if rangePart != "*" {
+			startPart, endPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseCronValue(startPart, min, max); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if end, err = parseCronValue(endPart, min, max); err != nil {
+					return 0, err
+				}
+				if end < start {
+					return 0, fmt.Errorf("invalid range '%s'", rangePart)
+				}
+			case hasStep:
+				// 'n/step' means from n to the max
+				end = max
+			default:
+				end = start
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, min, max int) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s'", value)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, min, max)
+	}
+	return v, nil
+}
+
+// next returns the first time after t which matches the expression (in the location of t)
+// if there is no matching time in the next 5 years, the zero time is returned
+func (c *cronExpression) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *cronExpression) matchesDay(t time.Time) bool {
+	dayOfMonth := c.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := c.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if c.dayOfMonthStar || c.dayOfWeekStar {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
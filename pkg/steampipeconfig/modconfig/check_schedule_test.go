@@ -0,0 +1,95 @@
+package modconfig
+
+import (
+	"testing"
+	"time"
+)
+
+type cronNextTest struct {
+	expr     string
+	from     time.Time
+	expected time.Time
+}
+
+// 2023-01-04 was a Wednesday
+var cronFrom = time.Date(2023, 1, 4, 10, 7, 30, 0, time.UTC)
+
+var cronNextCases = map[string]cronNextTest{
+	"every 15 minutes": {
+		expr:     "*/15 * * * *",
+		from:     cronFrom,
+		expected: time.Date(2023, 1, 4, 10, 15, 0, 0, time.UTC),
+	},
+	"hourly descriptor": {
+		expr:     "@hourly",
+		from:     cronFrom,
+		expected: time.Date(2023, 1, 4, 11, 0, 0, 0, time.UTC),
+	},
+	"daily descriptor": {
+		expr:     "@daily",
+		from:     cronFrom,
+		expected: time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC),
+	},
+	"weekdays at 9": {
+		expr:     "0 9 * * 1-5",
+		from:     time.Date(2023, 1, 6, 9, 0, 0, 0, time.UTC),
+		expected: time.Date(2023, 1, 9, 9, 0, 0, 0, time.UTC),
+	},
+	"sunday as 7": {
+		expr:     "30 2 * * 7",
+		from:     cronFrom,
+		expected: time.Date(2023, 1, 8, 2, 30, 0, 0, time.UTC),
+	},
+	"day of month or day of week": {
+		expr:     "0 0 15 * 5",
+		from:     cronFrom,
+		expected: time.Date(2023, 1, 6, 0, 0, 0, 0, time.UTC),
+	},
+	"list and range": {
+		expr:     "5,45 22-23 * 2 *",
+		from:     cronFrom,
+		expected: time.Date(2023, 2, 1, 22, 5, 0, 0, time.UTC),
+	},
+	"leap day": {
+		expr:     "0 0 29 2 *",
+		from:     cronFrom,
+		expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+	},
+	"never runs": {
+		expr:     "0 0 30 2 *",
+		from:     cronFrom,
+		expected: time.Time{},
+	},
+}
+
+func TestCronExpressionNext(t *testing.T) {
+	for name, test := range cronNextCases {
+		cron, err := parseCronExpression(test.expr)
+		if err != nil {
+			t.Errorf("Test: '%s' FAILED: unexpected error %s", name, err.Error())
+			continue
+		}
+		actual := cron.next(test.from)
+		if !actual.Equal(test.expected) {
+			t.Errorf("Test: '%s' FAILED: expected %s, got %s", name, test.expected, actual)
+		}
+	}
+}
+
+var cronInvalidCases = map[string]string{
+	"too few fields":  "* * * *",
+	"too many fields": "* * * * * *",
+	"minute range":    "60 * * * *",
+	"month zero":      "0 0 1 0 *",
+	"reversed range":  "0 5-1 * * *",
+	"zero step":       "*/0 * * * *",
+	"not a number":    "0 noon * * *",
+}
+
+func TestParseCronExpressionInvalid(t *testing.T) {
+	for name, expr := range cronInvalidCases {
+		if _, err := parseCronExpression(expr); err == nil {
+			t.Errorf("Test: '%s' FAILED: expected error parsing '%s'", name, expr)
+		}
+	}
+}
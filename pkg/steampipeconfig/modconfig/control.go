@@ -3,10 +3,13 @@ package modconfig
 import (
 	"fmt"
 	"strings"
+	"text/template"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/go-kit/types"
 	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/utils"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -20,6 +23,22 @@ type Control struct {
 	Remain hcl.Body `hcl:",remain" json:"-"`
 
 	Severity *string `cty:"severity" hcl:"severity"  column:"severity,text" json:"severity,omitempty"`
+	// the control query timeout in seconds (if not set, this is inherited from the parent benchmark)
+	Timeout *int `cty:"timeout" hcl:"timeout" column:"timeout,integer" json:"timeout,omitempty"`
+	// the number of times to retry the control query if it fails (if not set, this is inherited from the parent benchmark)
+	Retries *int `cty:"retries" hcl:"retries" column:"retries,integer" json:"retries,omitempty"`
+	// remediation guidance in markdown - this may reference the columns of a result row, e.g. {{ .resource }}
+	Remediation *string `cty:"remediation" hcl:"remediation" column:"remediation,text" json:"remediation,omitempty"`
+	// references for the control, e.g. urls or compliance section numbers
+	References []string `cty:"references" hcl:"references,optional" column:"references,jsonb" json:"references,omitempty"`
+	// the controls which must be run before this control
+	DependsOn NamedItemList `cty:"depends_on" hcl:"depends_on,optional" json:"-"`
+	// the statuses of a dependency which cause this control to be skipped (if not set, DefaultControlSkipIf is used)
+	SkipIf []string `cty:"skip_if" hcl:"skip_if,optional" column:"skip_if,jsonb" json:"skip_if,omitempty"`
+	// used for introspection tables
+	DependsOnNames []string `cty:"depends_on_names" column:"depends_on,jsonb" json:"depends_on,omitempty"`
+	// the weight of the control when calculating the score of its parent benchmark (if not set, DefaultWeight is used)
+	Weight *int `cty:"weight" hcl:"weight" column:"weight,integer" json:"weight,omitempty"`
 
 	// dashboard specific properties
 	Base    *Control `hcl:"base" json:"-"`
@@ -30,6 +49,12 @@ type Control struct {
 	parents []ModTreeItem
 }
 
+// DefaultControlSkipIf is the default list of dependency statuses which cause a control to be skipped
+var DefaultControlSkipIf = []string{constants.ControlAlarm, constants.ControlError}
+
+// DefaultWeight is the weight of a control or benchmark which does not specify a weight
+const DefaultWeight = 1
+
 func NewControl(block *hcl.Block, mod *Mod, shortName string) HclResource {
 	fullName := fmt.Sprintf("%s.%s.%s", mod.ShortName, block.Type, shortName)
 
@@ -61,6 +86,13 @@ func (c *Control) Equals(other *Control) bool {
 		typehelpers.SafeString(c.Description) == typehelpers.SafeString(other.Description) &&
 		typehelpers.SafeString(c.Documentation) == typehelpers.SafeString(other.Documentation) &&
 		typehelpers.SafeString(c.Severity) == typehelpers.SafeString(other.Severity) &&
+		utils.SafeIntEqual(c.Timeout, other.Timeout) &&
+		utils.SafeIntEqual(c.Retries, other.Retries) &&
+		typehelpers.SafeString(c.Remediation) == typehelpers.SafeString(other.Remediation) &&
+		utils.StringSlicesEqual(c.References, other.References) &&
+		utils.StringSlicesEqual(c.DependsOnNames, other.DependsOnNames) &&
+		utils.StringSlicesEqual(c.SkipIf, other.SkipIf) &&
+		utils.SafeIntEqual(c.Weight, other.Weight) &&
 		typehelpers.SafeString(c.SQL) == typehelpers.SafeString(other.SQL) &&
 		typehelpers.SafeString(c.Title) == typehelpers.SafeString(other.Title)
 	if !res {
@@ -163,7 +195,11 @@ func (c *Control) GetParentNames() []string {
 func (c *Control) OnDecoded(block *hcl.Block, resourceMapProvider ResourceMapsProvider) hcl.Diagnostics {
 	c.setBaseProperties()
 
-	return c.QueryProviderImpl.OnDecoded(block, resourceMapProvider)
+	diags := validateTimeoutAndRetries(c.Name(), c.Timeout, c.Retries, &c.DeclRange)
+	diags = append(diags, c.validateRemediation()...)
+	diags = append(diags, c.validateDependencies()...)
+	diags = append(diags, validateWeight(c.Name(), c.Weight, &c.DeclRange)...)
+	return append(diags, c.QueryProviderImpl.OnDecoded(block, resourceMapProvider)...)
 }
 
 // GetWidth implements DashboardLeafNode
@@ -199,6 +235,27 @@ func (c *Control) Diff(other *Control) *DashboardTreeItemDiffs {
 	if !utils.SafeStringsEqual(c.Severity, other.Severity) {
 		res.AddPropertyDiff("Severity")
 	}
+	if !utils.SafeIntEqual(c.Timeout, other.Timeout) {
+		res.AddPropertyDiff("Timeout")
+	}
+	if !utils.SafeIntEqual(c.Retries, other.Retries) {
+		res.AddPropertyDiff("Retries")
+	}
+	if !utils.SafeStringsEqual(c.Remediation, other.Remediation) {
+		res.AddPropertyDiff("Remediation")
+	}
+	if !utils.StringSlicesEqual(c.References, other.References) {
+		res.AddPropertyDiff("References")
+	}
+	if !utils.StringSlicesEqual(c.DependsOnNames, other.DependsOnNames) {
+		res.AddPropertyDiff("DependsOn")
+	}
+	if !utils.StringSlicesEqual(c.SkipIf, other.SkipIf) {
+		res.AddPropertyDiff("SkipIf")
+	}
+	if !utils.SafeIntEqual(c.Weight, other.Weight) {
+		res.AddPropertyDiff("Weight")
+	}
 	if len(c.Tags) != len(other.Tags) {
 		res.AddPropertyDiff("Tags")
 	} else {
@@ -232,6 +289,27 @@ func (c *Control) setBaseProperties() {
 	if c.Severity == nil {
 		c.Severity = c.Base.Severity
 	}
+	if c.Timeout == nil {
+		c.Timeout = c.Base.Timeout
+	}
+	if c.Retries == nil {
+		c.Retries = c.Base.Retries
+	}
+	if c.Remediation == nil {
+		c.Remediation = c.Base.Remediation
+	}
+	if c.References == nil {
+		c.References = c.Base.References
+	}
+	if c.DependsOn == nil {
+		c.DependsOn = c.Base.DependsOn
+	}
+	if c.SkipIf == nil {
+		c.SkipIf = c.Base.SkipIf
+	}
+	if c.Weight == nil {
+		c.Weight = c.Base.Weight
+	}
 
 	if c.Width == nil {
 		c.Width = c.Base.Width
@@ -243,3 +321,101 @@ func (c *Control) setBaseProperties() {
 		c.Display = c.Base.Display
 	}
 }
+
+// validateRemediation validates that the remediation is a valid template
+// (the remediation may reference the columns of a result row)
+func (c *Control) validateRemediation() hcl.Diagnostics {
+	if c.Remediation == nil {
+		return nil
+	}
+	if _, err := template.New("remediation").Parse(*c.Remediation); err != nil {
+		return hcl.Diagnostics{&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s has an invalid remediation", c.Name()),
+			Detail:   err.Error(),
+			Subject:  &c.DeclRange,
+		}}
+	}
+	return nil
+}
+
+// GetSkipIf returns the statuses of a dependency which cause the control to be skipped
+func (c *Control) GetSkipIf() []string {
+	if c.SkipIf == nil {
+		return DefaultControlSkipIf
+	}
+	return c.SkipIf
+}
+
+// validateDependencies validates the depends_on and skip_if properties, and populates DependsOnNames
+func (c *Control) validateDependencies() hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	c.DependsOnNames = c.DependsOn.StringList()
+	for _, name := range c.DependsOnNames {
+		if parsedName, err := ParseResourceName(name); err != nil || parsedName.ItemType != BlockTypeControl {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("%s has an invalid dependency '%s' - a control may only depend on other controls", c.Name(), name),
+				Subject:  &c.DeclRange,
+			})
+		}
+	}
+	if c.SkipIf != nil && len(c.DependsOnNames) == 0 {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s sets skip_if but has no depends_on", c.Name()),
+			Subject:  &c.DeclRange,
+		})
+	}
+	validStatuses := []string{constants.ControlOk, constants.ControlAlarm, constants.ControlInfo, constants.ControlSkip, constants.ControlError}
+	for _, status := range c.SkipIf {
+		if !helpers.StringSliceContains(validStatuses, status) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("%s has an invalid skip_if status '%s' - must be one of %s", c.Name(), status, strings.Join(validStatuses, ", ")),
+				Subject:  &c.DeclRange,
+			})
+		}
+	}
+	return diags
+}
+
+// GetWeight returns the weight of the control when calculating the score of its parent benchmark
+func (c *Control) GetWeight() int {
+	if c.Weight == nil {
+		return DefaultWeight
+	}
+	return *c.Weight
+}
+
+// validateTimeoutAndRetries validates the timeout and retries properties of a control or benchmark
+func validateTimeoutAndRetries(name string, timeout, retries *int, declRange *hcl.Range) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if timeout != nil && *timeout <= 0 {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s has an invalid timeout of %d - the timeout must be greater than 0", name, *timeout),
+			Subject:  declRange,
+		})
+	}
+	if retries != nil && *retries < 0 {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s has an invalid retries value of %d - retries must be 0 or greater", name, *retries),
+			Subject:  declRange,
+		})
+	}
+	return diags
+}
+
+// validateWeight validates the weight property of a control or benchmark
+func validateWeight(name string, weight *int, declRange *hcl.Range) hcl.Diagnostics {
+	if weight != nil && *weight < 0 {
+		return hcl.Diagnostics{&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s has an invalid weight of %d - the weight must be 0 or greater", name, *weight),
+			Subject:  declRange,
+		}}
+	}
+	return nil
+}
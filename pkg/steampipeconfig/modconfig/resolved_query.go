@@ -22,7 +22,7 @@ func (r ResolvedQuery) QueryArgs() *QueryArgs {
 		// TACTICAL convert to JSON representation
 		jsonBytes, err := json.Marshal(a)
 		argStr := string(jsonBytes)
-		if err != nil {
+		if err == nil {
 			res.ArgList[i] = &argStr
 		}
 	}
@@ -0,0 +1,28 @@
+package modconfig
+
+import (
+	"testing"
+)
+
+func TestResolvedQueryQueryArgs(t *testing.T) {
+	resolvedQuery := ResolvedQuery{
+		ExecuteSQL: "select $1, $2, $3",
+		Args:       []any{"val1", 2, true},
+	}
+	// the args are converted to their JSON representation
+	expected := []string{`"val1"`, `2`, `true`}
+
+	queryArgs := resolvedQuery.QueryArgs()
+	if len(queryArgs.ArgList) != len(expected) {
+		t.Fatalf("expected %d args, got %d", len(expected), len(queryArgs.ArgList))
+	}
+	for i, arg := range queryArgs.ArgList {
+		if arg == nil {
+			t.Errorf("arg %d: expected %s, got nil", i, expected[i])
+			continue
+		}
+		if *arg != expected[i] {
+			t.Errorf("arg %d: expected %s, got %s", i, expected[i], *arg)
+		}
+	}
+}
@@ -37,7 +37,9 @@ type WorkspaceProfile struct {
 	QueryOptions     *options.Query                     `cty:"query-options"`
 	CheckOptions     *options.Check                     `cty:"check-options"`
 	DashboardOptions *options.WorkspaceProfileDashboard `cty:"dashboard-options"`
-	DeclRange        hcl.Range
+	// check runs executed on a schedule by the steampipe service
+	Schedules []*CheckSchedule
+	DeclRange hcl.Range
 }
 
 func NewWorkspaceProfile(block *hcl.Block) *WorkspaceProfile {
@@ -47,6 +49,21 @@ func NewWorkspaceProfile(block *hcl.Block) *WorkspaceProfile {
 	}
 }
 
+// AddSchedule adds a check schedule to the profile - schedule names must be unique
+func (p *WorkspaceProfile) AddSchedule(schedule *CheckSchedule) hcl.Diagnostics {
+	for _, existing := range p.Schedules {
+		if existing.ScheduleName == schedule.ScheduleName {
+			return hcl.Diagnostics{&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("duplicate schedule '%s'", schedule.ScheduleName),
+				Subject:  &schedule.DeclRange,
+			}}
+		}
+	}
+	p.Schedules = append(p.Schedules, schedule)
+	return nil
+}
+
 // SetOptions sets the options on the connection
 // verify the options object is a valid options type (only options.Connection currently supported)
 func (p *WorkspaceProfile) SetOptions(opts options.Options, block *hcl.Block) hcl.Diagnostics {
@@ -154,6 +171,9 @@ func (p *WorkspaceProfile) setBaseProperties() {
 	if p.CacheTTL == nil {
 		p.CacheTTL = p.Base.CacheTTL
 	}
+	if p.Schedules == nil {
+		p.Schedules = p.Base.Schedules
+	}
 
 	// nested inheritance strategy:
 	//
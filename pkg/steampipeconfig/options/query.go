@@ -16,6 +16,21 @@ type Query struct {
 	Multi        *bool   `hcl:"multi" cty:"query_multi"`
 	Timing       *bool   `hcl:"timing" cty:"query_timing"`
 	AutoComplete *bool   `hcl:"autocomplete" cty:"query_autocomplete"`
+	// the maximum number of entries to keep in the query history
+	HistorySize *int `hcl:"history_size" cty:"query_history_size"`
+	// the maximum age (in days) of entries to keep in the query history
+	HistoryMaxAge *int `hcl:"history_max_age" cty:"query_history_max_age"`
+	// comma separated list of columns to hide in table and line output
+	HiddenColumns *string `hcl:"hidden_columns" cty:"query_hidden_columns"`
+	// comma separated list of per-column maximum widths, e.g. "description:60,tags:40"
+	ColumnWidths   *string `hcl:"column_widths" cty:"query_column_widths"`
+	MaxColumnWidth *int    `hcl:"max_column_width" cty:"query_max_column_width"`
+	// the rendering of json column values: pretty or compact
+	JsonFormat *string `hcl:"json_format" cty:"query_json_format"`
+	// the Go time layout used to display timestamp column values
+	TimestampFormat *string `hcl:"timestamp_format" cty:"query_timestamp_format"`
+	// the timezone used to display timestamptz column values, e.g. local, UTC or Europe/London
+	Timezone *string `hcl:"timezone" cty:"query_timezone"`
 }
 
 func (t *Query) SetBaseProperties(otherOptions Options) {
@@ -41,6 +56,30 @@ func (t *Query) SetBaseProperties(otherOptions Options) {
 		if t.AutoComplete == nil && o.AutoComplete != nil {
 			t.AutoComplete = o.AutoComplete
 		}
+		if t.HistorySize == nil && o.HistorySize != nil {
+			t.HistorySize = o.HistorySize
+		}
+		if t.HistoryMaxAge == nil && o.HistoryMaxAge != nil {
+			t.HistoryMaxAge = o.HistoryMaxAge
+		}
+		if t.HiddenColumns == nil && o.HiddenColumns != nil {
+			t.HiddenColumns = o.HiddenColumns
+		}
+		if t.ColumnWidths == nil && o.ColumnWidths != nil {
+			t.ColumnWidths = o.ColumnWidths
+		}
+		if t.MaxColumnWidth == nil && o.MaxColumnWidth != nil {
+			t.MaxColumnWidth = o.MaxColumnWidth
+		}
+		if t.JsonFormat == nil && o.JsonFormat != nil {
+			t.JsonFormat = o.JsonFormat
+		}
+		if t.TimestampFormat == nil && o.TimestampFormat != nil {
+			t.TimestampFormat = o.TimestampFormat
+		}
+		if t.Timezone == nil && o.Timezone != nil {
+			t.Timezone = o.Timezone
+		}
 	}
 }
 
@@ -66,6 +105,32 @@ func (t *Query) ConfigMap() map[string]interface{} {
 	if t.AutoComplete != nil {
 		res[constants.ArgAutoComplete] = t.AutoComplete
 	}
+	if t.HistorySize != nil {
+		res[constants.ArgHistorySize] = t.HistorySize
+	}
+	if t.HistoryMaxAge != nil {
+		res[constants.ArgHistoryMaxAge] = t.HistoryMaxAge
+	}
+	if t.HiddenColumns != nil {
+		// convert from string to array
+		res[constants.ArgHiddenColumns] = searchPathToArray(*t.HiddenColumns)
+	}
+	if t.ColumnWidths != nil {
+		// convert from string to array
+		res[constants.ArgColumnWidths] = searchPathToArray(*t.ColumnWidths)
+	}
+	if t.MaxColumnWidth != nil {
+		res[constants.ArgMaxColumnWidth] = t.MaxColumnWidth
+	}
+	if t.JsonFormat != nil {
+		res[constants.ArgJsonFormat] = t.JsonFormat
+	}
+	if t.TimestampFormat != nil {
+		res[constants.ArgTimestampFormat] = t.TimestampFormat
+	}
+	if t.Timezone != nil {
+		res[constants.ArgTimezone] = t.Timezone
+	}
 	return res
 }
 
@@ -95,6 +160,30 @@ func (t *Query) Merge(otherOptions Options) {
 		if o.AutoComplete != nil {
 			t.AutoComplete = o.AutoComplete
 		}
+		if o.HistorySize != nil {
+			t.HistorySize = o.HistorySize
+		}
+		if o.HistoryMaxAge != nil {
+			t.HistoryMaxAge = o.HistoryMaxAge
+		}
+		if o.HiddenColumns != nil {
+			t.HiddenColumns = o.HiddenColumns
+		}
+		if o.ColumnWidths != nil {
+			t.ColumnWidths = o.ColumnWidths
+		}
+		if o.MaxColumnWidth != nil {
+			t.MaxColumnWidth = o.MaxColumnWidth
+		}
+		if o.JsonFormat != nil {
+			t.JsonFormat = o.JsonFormat
+		}
+		if o.TimestampFormat != nil {
+			t.TimestampFormat = o.TimestampFormat
+		}
+		if o.Timezone != nil {
+			t.Timezone = o.Timezone
+		}
 	}
 }
 
@@ -133,5 +222,45 @@ func (t *Query) String() string {
 	} else {
 		str = append(str, fmt.Sprintf("  AutoComplete: %v", *t.AutoComplete))
 	}
+	if t.HistorySize == nil {
+		str = append(str, "  HistorySize: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  HistorySize: %d", *t.HistorySize))
+	}
+	if t.HistoryMaxAge == nil {
+		str = append(str, "  HistoryMaxAge: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  HistoryMaxAge: %d", *t.HistoryMaxAge))
+	}
+	if t.HiddenColumns == nil {
+		str = append(str, "  HiddenColumns: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  HiddenColumns: %s", *t.HiddenColumns))
+	}
+	if t.ColumnWidths == nil {
+		str = append(str, "  ColumnWidths: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  ColumnWidths: %s", *t.ColumnWidths))
+	}
+	if t.MaxColumnWidth == nil {
+		str = append(str, "  MaxColumnWidth: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  MaxColumnWidth: %d", *t.MaxColumnWidth))
+	}
+	if t.JsonFormat == nil {
+		str = append(str, "  JsonFormat: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  JsonFormat: %s", *t.JsonFormat))
+	}
+	if t.TimestampFormat == nil {
+		str = append(str, "  TimestampFormat: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  TimestampFormat: %s", *t.TimestampFormat))
+	}
+	if t.Timezone == nil {
+		str = append(str, "  Timezone: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  Timezone: %s", *t.Timezone))
+	}
 	return strings.Join(str, "\n")
 }
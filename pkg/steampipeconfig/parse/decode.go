@@ -609,6 +609,15 @@ func decodeBenchmark(block *hcl.Block, parseCtx *ModParseContext) (*modconfig.Be
 	diags = decodeProperty(content, "display", &benchmark.Display, parseCtx.EvalCtx)
 	res.handleDecodeDiags(diags)
 
+	diags = decodeProperty(content, "timeout", &benchmark.Timeout, parseCtx.EvalCtx)
+	res.handleDecodeDiags(diags)
+
+	diags = decodeProperty(content, "retries", &benchmark.Retries, parseCtx.EvalCtx)
+	res.handleDecodeDiags(diags)
+
+	diags = decodeProperty(content, "weight", &benchmark.Weight, parseCtx.EvalCtx)
+	res.handleDecodeDiags(diags)
+
 	// now add children
 	if res.Success() {
 		supportedChildren := []string{modconfig.BlockTypeBenchmark, modconfig.BlockTypeControl}
@@ -2,6 +2,7 @@ package parse
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
@@ -183,3 +184,46 @@ func parsePositionalArgs(argsList []string) ([]any, error) {
 
 	return res, nil
 }
+
+// namedArgRegex matches a command line arg of the form name=value
+var namedArgRegex = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_-]*)=(.*)$`)
+
+// ParseCommandLineArgs parses query args passed on the command line using the --arg flag
+// supported formats are:
+//
+// 1) positional args
+// --arg val1 --arg val2
+//
+// 2) named args
+// --arg my_arg1=val1 --arg my_arg2=val2
+//
+// all values are strings - when the args are bound to a named query, they are converted to the type of the query params
+func ParseCommandLineArgs(argStrings []string) (*modconfig.QueryArgs, error) {
+	res := modconfig.NewQueryArgs()
+	if len(argStrings) == 0 {
+		return res, nil
+	}
+
+	argMap := make(map[string]any)
+	var argList []any
+	for _, argString := range argStrings {
+		if match := namedArgRegex.FindStringSubmatch(argString); match != nil {
+			argMap[match[1]] = match[2]
+		} else {
+			argList = append(argList, argString)
+		}
+	}
+
+	if len(argMap) > 0 && len(argList) > 0 {
+		return nil, fmt.Errorf("args must be either all positional or all named (name=value)")
+	}
+	if err := res.SetArgMap(argMap); err != nil {
+		return nil, err
+	}
+	if len(argList) > 0 {
+		if err := res.SetArgList(argList); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
@@ -2,6 +2,7 @@ package parse
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/turbot/steampipe/pkg/utils"
@@ -125,3 +126,66 @@ func TestParseQueryInvocation(t *testing.T) {
 		}
 	}
 }
+
+type parseCommandLineArgsTest struct {
+	input        []string
+	expectedList []any
+	expectedMap  map[string]any
+	expectError  bool
+}
+
+var testCasesParseCommandLineArgs = map[string]parseCommandLineArgsTest{
+	"no args": {
+		input: nil,
+	},
+	"positional string": {
+		input:        []string{"us-east-1"},
+		expectedList: []any{"us-east-1"},
+	},
+	"positional values are not converted": {
+		input:        []string{"012345678901", "true", `["a","b"]`},
+		expectedList: []any{"012345678901", "true", `["a","b"]`},
+	},
+	"named": {
+		input:       []string{"region=us-east-1", "limit=5", "filter=a=b"},
+		expectedMap: map[string]any{"region": "us-east-1", "limit": "5", "filter": "a=b"},
+	},
+	"mixed": {
+		input:       []string{"region=us-east-1", "foo"},
+		expectError: true,
+	},
+}
+
+func TestParseCommandLineArgs(t *testing.T) {
+	for name, test := range testCasesParseCommandLineArgs {
+		args, err := ParseCommandLineArgs(test.input)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("Test: '%s' FAILED : expected error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: '%s' FAILED : unexpected error %v", name, err)
+			continue
+		}
+
+		argList, err := args.ConvertArgsList()
+		if err != nil {
+			t.Errorf("Test: '%s' FAILED : unexpected error %v", name, err)
+			continue
+		}
+		if len(argList) != len(test.expectedList) || (len(argList) > 0 && !reflect.DeepEqual(argList, test.expectedList)) {
+			t.Errorf("Test: '%s' FAILED : expected args list %v, got %v", name, test.expectedList, argList)
+		}
+		if len(args.ArgMap) != len(test.expectedMap) {
+			t.Errorf("Test: '%s' FAILED : expected args map %v, got %v", name, test.expectedMap, args.ArgMap)
+		}
+		for k, expected := range test.expectedMap {
+			actual, _, err := args.GetNamedArg(k)
+			if err != nil || !reflect.DeepEqual(actual, expected) {
+				t.Errorf("Test: '%s' FAILED : expected arg %s to be %v, got %v", name, k, expected, actual)
+			}
+		}
+	}
+}
@@ -36,6 +36,10 @@ var WorkspaceProfileBlockSchema = &hcl.BodySchema{
 			Type:       "options",
 			LabelNames: []string{"type"},
 		},
+		{
+			Type:       "schedule",
+			LabelNames: []string{"name"},
+		},
 	},
 }
 
@@ -246,6 +250,9 @@ var BenchmarkBlockSchema = &hcl.BodySchema{
 		{Name: "documentation"},
 		{Name: "tags"},
 		{Name: "title"},
+		{Name: "timeout"},
+		{Name: "retries"},
+		{Name: "weight"},
 		// for report benchmark blocks
 		{Name: "width"},
 		{Name: "base"},
@@ -172,11 +172,19 @@ func decodeWorkspaceProfile(block *hcl.Block, parseCtx *WorkspaceProfileParseCon
 				diags = append(diags, moreDiags...)
 			}
 			foundOptions[optionsBlockType] = struct{}{}
+		case modconfig.BlockTypeSchedule:
+			// add schedule diags directly to the decode result, so an invalid schedule fails the profile decode
+			schedule, moreDiags := decodeCheckSchedule(block)
+			res.addDiags(moreDiags)
+			if moreDiags.HasErrors() {
+				break
+			}
+			res.addDiags(resource.AddSchedule(schedule))
 		default:
 			// this should never happen
 			diags = append(diags, &hcl.Diagnostic{
 				Severity: hcl.DiagError,
-				Summary:  fmt.Sprintf("invalid block type '%s' - only 'options' blocks are supported for workspace profiles", block.Type),
+				Summary:  fmt.Sprintf("invalid block type '%s' - only 'options' and 'schedule' blocks are supported for workspace profiles", block.Type),
 				Subject:  &block.DefRange,
 			})
 		}
@@ -186,6 +194,17 @@ func decodeWorkspaceProfile(block *hcl.Block, parseCtx *WorkspaceProfileParseCon
 	return resource, res
 }
 
+// decodeCheckSchedule decodes a schedule block and validates its cron expression
+func decodeCheckSchedule(block *hcl.Block) (*modconfig.CheckSchedule, hcl.Diagnostics) {
+	schedule := modconfig.NewCheckSchedule(block)
+	diags := gohcl.DecodeBody(block.Body, nil, schedule)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	diags = append(diags, schedule.OnDecoded()...)
+	return schedule, diags
+}
+
 func handleWorkspaceProfileDecodeResult(resource *modconfig.WorkspaceProfile, res *DecodeResult, block *hcl.Block, parseCtx *WorkspaceProfileParseContext) {
 	if res.Success() {
 		// call post decode hook
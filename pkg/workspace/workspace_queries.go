@@ -1,6 +1,7 @@
 package workspace
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -18,13 +19,14 @@ import (
 // GetQueriesFromArgs retrieves queries from args
 //
 // For each arg check if it is a named query or a file, before falling back to treating it as sql
-func (w *Workspace) GetQueriesFromArgs(args []string) (map[string]*modconfig.ResolvedQuery, error) {
+// runtimeArgs (passed using --arg) are bound to each query
+func (w *Workspace) GetQueriesFromArgs(args []string, runtimeArgs *modconfig.QueryArgs) (map[string]*modconfig.ResolvedQuery, error) {
 	utils.LogTime("execute.GetQueriesFromArgs start")
 	defer utils.LogTime("execute.GetQueriesFromArgs end")
 
 	var queries = make(map[string]*modconfig.ResolvedQuery)
 	for _, arg := range args {
-		resolvedQuery, queryProvider, err := w.ResolveQueryAndArgsFromSQLString(arg)
+		resolvedQuery, queryProvider, err := w.resolveQueryAndArgsFromSQLString(arg, runtimeArgs)
 		if err != nil {
 			return nil, err
 		}
@@ -42,6 +44,11 @@ func (w *Workspace) GetQueriesFromArgs(args []string) (map[string]*modconfig.Res
 
 // ResolveQueryAndArgsFromSQLString attempts to resolve 'arg' to a query and query args
 func (w *Workspace) ResolveQueryAndArgsFromSQLString(sqlString string) (*modconfig.ResolvedQuery, modconfig.QueryProvider, error) {
+	return w.resolveQueryAndArgsFromSQLString(sqlString, nil)
+}
+
+// resolveQueryAndArgsFromSQLString resolves 'arg' to a query and query args, binding the given runtime args (if any)
+func (w *Workspace) resolveQueryAndArgsFromSQLString(sqlString string, runtimeArgs *modconfig.QueryArgs) (*modconfig.ResolvedQuery, modconfig.QueryProvider, error) {
 	var args = &modconfig.QueryArgs{}
 
 	var err error
@@ -56,6 +63,17 @@ func (w *Workspace) ResolveQueryAndArgsFromSQLString(sqlString string) (*modconf
 	if resource != nil {
 		log.Printf("[TRACE] query string is a query provider resource: %s", resource.Name())
 
+		// if runtime args were passed, use them (unless the invocation itself has args)
+		if runtimeArgs != nil && !runtimeArgs.Empty() {
+			if args != nil && !args.Empty() {
+				return nil, nil, fmt.Errorf("cannot pass --arg values to '%s' as its invocation already specifies args", resource.Name())
+			}
+			args, err = convertRuntimeArgs(runtimeArgs, resource.GetParams())
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
 		// resolve the query for the query provider and return it
 		resolvedQuery, err := w.ResolveQueryFromQueryProvider(resource, args)
 		if err != nil {
@@ -74,6 +92,10 @@ func (w *Workspace) ResolveQueryAndArgsFromSQLString(sqlString string) (*modconf
 		if fileQuery == nil {
 			error_helpers.ShowWarning(fmt.Sprintf("file '%s' does not contain any data", sqlString))
 			// (just return the empty query - it will be filtered above)
+			return fileQuery, nil, nil
+		}
+		if err := bindRuntimeArgs(fileQuery, runtimeArgs); err != nil {
+			return nil, nil, err
 		}
 		return fileQuery, nil, nil
 	}
@@ -84,7 +106,82 @@ func (w *Workspace) ResolveQueryAndArgsFromSQLString(sqlString string) (*modconf
 	}
 
 	// 4) just use the query string as is and assume it is valid SQL
-	return &modconfig.ResolvedQuery{RawSQL: sqlString, ExecuteSQL: sqlString}, nil, nil
+	resolvedQuery := &modconfig.ResolvedQuery{RawSQL: sqlString, ExecuteSQL: sqlString}
+	if err := bindRuntimeArgs(resolvedQuery, runtimeArgs); err != nil {
+		return nil, nil, err
+	}
+	return resolvedQuery, nil, nil
+}
+
+// convertRuntimeArgs converts the (string) runtime arg values to the type of the corresponding param
+// the type of a param is given by its default - values for params with a string default or no default are left as strings
+func convertRuntimeArgs(runtimeArgs *modconfig.QueryArgs, params []*modconfig.ParamDef) (*modconfig.QueryArgs, error) {
+	paramMap := make(map[string]*modconfig.ParamDef, len(params))
+	for _, p := range params {
+		paramMap[p.ShortName] = p
+	}
+
+	res := modconfig.NewQueryArgs()
+	if len(runtimeArgs.ArgMap) > 0 {
+		argMap := make(map[string]any, len(runtimeArgs.ArgMap))
+		for name, value := range runtimeArgs.ArgMap {
+			argValue, err := convertRuntimeArg(value, paramMap[name])
+			if err != nil {
+				return nil, err
+			}
+			argMap[name] = argValue
+		}
+		if err := res.SetArgMap(argMap); err != nil {
+			return nil, err
+		}
+	}
+	if len(runtimeArgs.ArgList) > 0 {
+		argList := make([]any, len(runtimeArgs.ArgList))
+		for i, value := range runtimeArgs.ArgList {
+			var param *modconfig.ParamDef
+			if i < len(params) {
+				param = params[i]
+			}
+			argValue, err := convertRuntimeArg(typehelpers.SafeString(value), param)
+			if err != nil {
+				return nil, err
+			}
+			argList[i] = argValue
+		}
+		if err := res.SetArgList(argList); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func convertRuntimeArg(value string, param *modconfig.ParamDef) (any, error) {
+	if param == nil || param.Default == nil || param.IsString {
+		return value, nil
+	}
+	// the param default is not a string, so parse the value as JSON (e.g. a number, boolean or list)
+	var res any
+	if err := json.Unmarshal([]byte(value), &res); err != nil {
+		return nil, fmt.Errorf("invalid --arg value for param '%s': '%s' does not match the type of the param default (%s)", param.ShortName, value, *param.Default)
+	}
+	return res, nil
+}
+
+// bindRuntimeArgs sets the args of a query which was not resolved from a query provider (i.e. raw sql or a sql file)
+// as there are no param definitions for these queries, only positional args are supported
+func bindRuntimeArgs(resolvedQuery *modconfig.ResolvedQuery, runtimeArgs *modconfig.QueryArgs) error {
+	if runtimeArgs == nil || runtimeArgs.Empty() {
+		return nil
+	}
+	if len(runtimeArgs.ArgMap) > 0 {
+		return fmt.Errorf("named args can only be used with named queries which define 'param' blocks - use positional args to bind $1, $2... parameters")
+	}
+	args, err := runtimeArgs.ConvertArgsList()
+	if err != nil {
+		return err
+	}
+	resolvedQuery.Args = args
+	return nil
 }
 
 // ResolveQueryFromQueryProvider resolves the query for the given QueryProvider
@@ -160,6 +257,12 @@ func (w *Workspace) getQueryFromFile(input string) (*modconfig.ResolvedQuery, bo
 	return res, true, nil
 }
 
+// IsQueryProvider returns whether the input is the name (or invocation) of a query provider in the workspace
+func (w *Workspace) IsQueryProvider(input string) bool {
+	queryProvider, _, err := w.extractQueryProviderFromQueryString(input)
+	return err == nil && queryProvider != nil
+}
+
 // does the input look like a resource which can be executed as a query
 // Note: if anything fails just return nil values
 func (w *Workspace) extractQueryProviderFromQueryString(input string) (modconfig.QueryProvider, *modconfig.QueryArgs, error) {
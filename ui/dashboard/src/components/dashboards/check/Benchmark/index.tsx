@@ -9,6 +9,7 @@ import usePanelControls from "../../../../hooks/usePanelControls";
 import {
   BenchmarkTreeProps,
   CheckDisplayGroup,
+  CheckGroupSummary,
   CheckNode,
   CheckSummary,
 } from "../common";
@@ -31,9 +32,13 @@ type BenchmarkTableViewProps = {
   definition: PanelDefinition;
 };
 
+type BenchmarkDefinition = PanelDefinition & {
+  summary?: CheckGroupSummary;
+};
+
 type InnerCheckProps = {
   benchmark: BenchmarkType;
-  definition: PanelDefinition;
+  definition: BenchmarkDefinition;
   grouping: CheckNode;
   groupingConfig: CheckDisplayGroup[];
   firstChildSummaries: CheckSummary[];
@@ -154,8 +159,28 @@ const Benchmark = (props: InnerCheckProps) => {
         },
       });
     }
+
+    // If scoring is enabled, the server returns the weighted compliance score of the benchmark
+    const score = props.definition.summary?.score;
+    if (score !== undefined && score !== null) {
+      summary_cards.push({
+        name: `${props.definition.name}.container.summary.score`,
+        width: 2,
+        display_type: score >= 100 ? "ok" : "alert",
+        properties: {
+          label: "Score (%)",
+          value: score,
+          icon: "materialsymbols-solid:verified",
+        },
+      });
+    }
     return summary_cards;
-  }, [props.firstChildSummaries, props.grouping, props.definition.name]);
+  }, [
+    props.firstChildSummaries,
+    props.grouping,
+    props.definition.name,
+    props.definition.summary,
+  ]);
 
   if (!props.grouping) {
     return null;
@@ -54,6 +54,12 @@ export type CheckSummary = {
   error: number;
 };
 
+// The summary of a benchmark as returned by the server, including the weighted compliance score
+export type CheckGroupSummary = {
+  status: CheckSummary;
+  score?: number;
+};
+
 export type CheckDynamicValueMap = {
   [dimension: string]: boolean;
 };